@@ -0,0 +1,5 @@
+data "kafka_brokers" "example" {}
+
+output "racks" {
+  value = { for broker in data.kafka_brokers.example.brokers : broker.id => broker.rack }
+}
@@ -0,0 +1,5 @@
+data "kafka_cluster" "example" {}
+
+output "controller_id" {
+  value = data.kafka_cluster.example.controller_id
+}
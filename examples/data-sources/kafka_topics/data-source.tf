@@ -0,0 +1,7 @@
+data "kafka_topics" "example" {
+  name_prefix = "orders."
+}
+
+output "topic_names" {
+  value = data.kafka_topics.example.topics[*].name
+}
@@ -0,0 +1,9 @@
+resource "kafka_topic" "orders" {
+  name               = "orders"
+  partitions         = 12
+  replication_factor = 3
+}
+
+output "customer_partition" {
+  value = provider::kafka::partition_for_key("customer-42", kafka_topic.orders.partitions, "murmur2")
+}
@@ -0,0 +1,2 @@
+# ACLs can be imported using resource_type|resource_name|resource_pattern_type|principal|host|operation|permission_type
+terraform import kafka_acl.example 'topic|example|literal|User:example|*|read|allow'
@@ -0,0 +1,9 @@
+resource "kafka_acl" "example" {
+  resource_type         = "topic"
+  resource_name         = "example"
+  resource_pattern_type = "literal"
+  principal             = "User:example"
+  host                  = "*"
+  operation             = "read"
+  permission_type       = "allow"
+}
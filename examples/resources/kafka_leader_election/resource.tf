@@ -0,0 +1,15 @@
+resource "kafka_topic" "example" {
+  name               = "example"
+  partitions         = 3
+  replication_factor = 3
+  replica_assignment = [[1, 2, 3], [2, 3, 1], [3, 1, 2]]
+}
+
+# Move the leadership back to the preferred replicas whenever the assignment
+# changes
+resource "kafka_leader_election" "example" {
+  topic = kafka_topic.example.name
+  triggers = {
+    replica_assignment = jsonencode(kafka_topic.example.replica_assignment)
+  }
+}
@@ -0,0 +1,3 @@
+# Quotas can be imported using type=name pairs separated by |, with <default> for default entities
+terraform import kafka_quota.example 'user=example|client-id=example-app'
+terraform import kafka_quota.default 'client-id=<default>'
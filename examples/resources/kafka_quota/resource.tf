@@ -0,0 +1,21 @@
+# Quota for a specific user and client-id combination
+resource "kafka_quota" "example" {
+  entities = [
+    { type = "user", name = "example" },
+    { type = "client-id", name = "example-app" },
+  ]
+  quotas = {
+    producer_byte_rate = 1048576
+    consumer_byte_rate = 2097152
+  }
+}
+
+# Default quota for every client-id
+resource "kafka_quota" "default" {
+  entities = [
+    { type = "client-id" },
+  ]
+  quotas = {
+    request_percentage = 200
+  }
+}
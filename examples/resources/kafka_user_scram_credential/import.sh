@@ -0,0 +1,2 @@
+# SCRAM credentials can be imported using username|mechanism
+terraform import kafka_user_scram_credential.example 'example|scram-sha-512'
@@ -0,0 +1,6 @@
+resource "kafka_user_scram_credential" "example" {
+  username   = "example"
+  mechanism  = "scram-sha-512"
+  iterations = 8192
+  password   = var.example_password
+}
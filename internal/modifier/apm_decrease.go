@@ -0,0 +1,68 @@
+package modifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Int64RejectDecrease fails the plan when the value is lower than the one in
+// state, unless the boolean attribute at replacePath is true, in which case the
+// resource is marked for replacement instead.
+func Int64RejectDecrease(replacePath path.Path) planmodifier.Int64 {
+	return &int64RejectDecreasePlanModifier{replacePath}
+}
+
+type int64RejectDecreasePlanModifier struct {
+	ReplacePath path.Path
+}
+
+var _ planmodifier.Int64 = (*int64RejectDecreasePlanModifier)(nil)
+
+func (apm *int64RejectDecreasePlanModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, res *planmodifier.Int64Response) {
+	// Nothing to compare against on create, and nothing to do on destroy
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() {
+		return
+	}
+	if req.StateValue.IsUnknown() || req.PlanValue.IsUnknown() {
+		return
+	}
+	if req.PlanValue.ValueInt64() >= req.StateValue.ValueInt64() {
+		return
+	}
+
+	var replace types.Bool
+	res.Diagnostics.Append(req.Config.GetAttribute(ctx, apm.ReplacePath, &replace)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	// The value may still allow the replacement once it is known, the apply
+	// fails the decrease otherwise
+	if replace.IsUnknown() {
+		return
+	}
+	if replace.ValueBool() {
+		res.RequiresReplace = true
+		return
+	}
+
+	res.Diagnostics.AddAttributeError(
+		req.Path,
+		"Value can't be decreased",
+		fmt.Sprintf(
+			"%s can't be decreased from %d to %d. Set %s to true to replace the resource instead.",
+			req.Path, req.StateValue.ValueInt64(), req.PlanValue.ValueInt64(), apm.ReplacePath,
+		),
+	)
+}
+
+func (apm int64RejectDecreasePlanModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Rejects decreasing the value, unless %s is set, in which case the resource is replaced", apm.ReplacePath)
+}
+
+func (apm int64RejectDecreasePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return apm.Description(ctx)
+}
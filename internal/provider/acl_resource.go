@@ -0,0 +1,421 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pecigonzalo/terraform-provider-kafka/internal/modifier"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                   = &aclResource{}
+	_ resource.ResourceWithConfigure      = &aclResource{}
+	_ resource.ResourceWithImportState    = &aclResource{}
+	_ resource.ResourceWithValidateConfig = &aclResource{}
+)
+
+// aclIDSeparator separates the fields of the composite ACL ID. Principals
+// already contain ":" (e.g. User:alice), so we can't use that.
+const aclIDSeparator = "|"
+
+var (
+	aclResourceTypes   = []string{"topic", "group", "cluster", "transactionalid", "delegationtoken"}
+	aclPatternTypes    = []string{"literal", "prefixed"}
+	aclOperations      = []string{"all", "read", "write", "create", "delete", "alter", "describe", "clusteraction", "describeconfigs", "alterconfigs", "idempotentwrite"}
+	aclPermissionTypes = []string{"allow", "deny"}
+)
+
+func NewACLResource() resource.Resource {
+	return &aclResource{}
+}
+
+// aclResource defines the resource implementation.
+type aclResource struct {
+	client *admin.BrokerAdminClient
+}
+
+// ACLResourceModel describes the resource data model.
+type ACLResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ResourceType   types.String `tfsdk:"resource_type"`
+	ResourceName   types.String `tfsdk:"resource_name"`
+	PatternType    types.String `tfsdk:"resource_pattern_type"`
+	Principal      types.String `tfsdk:"principal"`
+	Host           types.String `tfsdk:"host"`
+	Operation      types.String `tfsdk:"operation"`
+	PermissionType types.String `tfsdk:"permission_type"`
+}
+
+func (r *aclResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acl"
+}
+
+func (r *aclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Kafka ACL resource. ACL bindings can't be modified, so any change replaces the binding.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ACL id, composed of all the binding fields separated by `|`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"resource_type": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Resource type. One of %s", strings.Join(aclResourceTypes, ", ")),
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource_name": schema.StringAttribute{
+				MarkdownDescription: "Resource name, or prefix when `resource_pattern_type` is prefixed",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource_pattern_type": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Resource pattern type. One of %s (default: literal)", strings.Join(aclPatternTypes, ", ")),
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					modifier.StringDefaultValue(types.StringValue("literal")),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principal": schema.StringAttribute{
+				MarkdownDescription: "Principal the ACL applies to, e.g. `User:alice`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "Host the ACL applies to (default: *)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					modifier.StringDefaultValue(types.StringValue("*")),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"operation": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Operation. One of %s", strings.Join(aclOperations, ", ")),
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permission_type": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Permission type. One of %s", strings.Join(aclPermissionTypes, ", ")),
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *aclResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*kafkaResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *kafkaResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *aclResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ACLResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateOneOf := func(attribute string, value types.String, valid []string) {
+		if value.IsNull() || value.IsUnknown() {
+			return
+		}
+		for _, v := range valid {
+			if value.ValueString() == v {
+				return
+			}
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"Invalid ACL attribute value",
+			fmt.Sprintf("%q is not a valid %s, must be one of: %s", value.ValueString(), attribute, strings.Join(valid, ", ")),
+		)
+	}
+	validateOneOf("resource_type", data.ResourceType, aclResourceTypes)
+	validateOneOf("resource_pattern_type", data.PatternType, aclPatternTypes)
+	validateOneOf("operation", data.Operation, aclOperations)
+	validateOneOf("permission_type", data.PermissionType, aclPermissionTypes)
+}
+
+func (r *aclResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ACLResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := data.toACLEntry()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ACL", err.Error())
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating ACL %s", data.id()))
+	clientResp, err := r.client.GetConnector().KafkaClient.CreateACLs(ctx, &kafka.CreateACLsRequest{
+		ACLs: []kafka.ACLEntry{entry},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create ACL, got error: %s", err))
+		return
+	}
+	for _, v := range clientResp.Errors {
+		if v != nil {
+			resp.Diagnostics.AddError("Client Response Error", fmt.Sprintf("Unable to create ACL, got error: %s", v))
+			return
+		}
+	}
+	data.ID = types.StringValue(data.id())
+	tflog.Trace(ctx, "Created ACL")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *aclResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ACLResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := data.toACLEntry()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ACL", err.Error())
+		return
+	}
+
+	clientResp, err := r.client.GetConnector().KafkaClient.DescribeACLs(ctx, &kafka.DescribeACLsRequest{
+		Filter: kafka.ACLFilter{
+			ResourceTypeFilter:        entry.ResourceType,
+			ResourceNameFilter:        entry.ResourceName,
+			ResourcePatternTypeFilter: entry.ResourcePatternType,
+			PrincipalFilter:           entry.Principal,
+			HostFilter:                entry.Host,
+			Operation:                 entry.Operation,
+			PermissionType:            entry.PermissionType,
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL, got error: %s", err))
+		return
+	}
+	if clientResp.Error != nil {
+		resp.Diagnostics.AddError("Client Response Error", fmt.Sprintf("Unable to read ACL, got error: %s", clientResp.Error))
+		return
+	}
+
+	found := false
+	for _, res := range clientResp.Resources {
+		if len(res.ACLs) > 0 {
+			found = true
+		}
+	}
+	if !found {
+		// If the ACL does not exist, we remove it and return
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *aclResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update in place
+	var data *ACLResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *aclResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ACLResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := data.toACLEntry()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ACL", err.Error())
+		return
+	}
+
+	clientResp, err := r.client.GetConnector().KafkaClient.DeleteACLs(ctx, &kafka.DeleteACLsRequest{
+		Filters: []kafka.DeleteACLsFilter{
+			{
+				ResourceTypeFilter:        entry.ResourceType,
+				ResourceNameFilter:        entry.ResourceName,
+				ResourcePatternTypeFilter: entry.ResourcePatternType,
+				PrincipalFilter:           entry.Principal,
+				HostFilter:                entry.Host,
+				Operation:                 entry.Operation,
+				PermissionType:            entry.PermissionType,
+			},
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete ACL, got error: %s", err))
+		return
+	}
+	for _, result := range clientResp.Results {
+		if result.Error != nil {
+			resp.Diagnostics.AddError("Client Response Error", fmt.Sprintf("Unable to delete ACL, got error: %s", result.Error))
+			return
+		}
+		for _, match := range result.MatchingACLs {
+			if match.Error != nil {
+				resp.Diagnostics.AddError("Client Response Error", fmt.Sprintf("Unable to delete ACL, got error: %s", match.Error))
+				return
+			}
+		}
+	}
+}
+
+func (r *aclResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	data, err := parseACLID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: resource_type|resource_name|resource_pattern_type|principal|host|operation|permission_type. Got: %q, error: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// id returns the composite ID for the ACL binding
+func (m *ACLResourceModel) id() string {
+	return strings.Join([]string{
+		m.ResourceType.ValueString(),
+		m.ResourceName.ValueString(),
+		m.PatternType.ValueString(),
+		m.Principal.ValueString(),
+		m.Host.ValueString(),
+		m.Operation.ValueString(),
+		m.PermissionType.ValueString(),
+	}, aclIDSeparator)
+}
+
+// toACLEntry converts the model into a kafka.ACLEntry
+func (m *ACLResourceModel) toACLEntry() (kafka.ACLEntry, error) {
+	entry := kafka.ACLEntry{
+		ResourceName: m.ResourceName.ValueString(),
+		Principal:    m.Principal.ValueString(),
+		Host:         m.Host.ValueString(),
+	}
+	if err := entry.ResourceType.UnmarshalText([]byte(m.ResourceType.ValueString())); err != nil {
+		return entry, err
+	}
+	if err := entry.ResourcePatternType.UnmarshalText([]byte(m.PatternType.ValueString())); err != nil {
+		return entry, err
+	}
+	if err := entry.Operation.UnmarshalText([]byte(m.Operation.ValueString())); err != nil {
+		return entry, err
+	}
+	if err := entry.PermissionType.UnmarshalText([]byte(m.PermissionType.ValueString())); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
+
+// parseACLID parses a composite ACL ID into a ACLResourceModel
+func parseACLID(id string) (ACLResourceModel, error) {
+	parts := strings.Split(id, aclIDSeparator)
+	if len(parts) != 7 {
+		return ACLResourceModel{}, fmt.Errorf("expected 7 fields, got %d", len(parts))
+	}
+	for _, part := range parts {
+		if part == "" {
+			return ACLResourceModel{}, fmt.Errorf("fields can't be empty")
+		}
+	}
+	// kafka-go parses the values ignoring case, and accepts the filter values
+	// such as any, which would delete every matching ACL on destroy, so we only
+	// accept the exact values of the configuration
+	fields := []struct {
+		name  string
+		value string
+		valid []string
+	}{
+		{"resource_type", parts[0], aclResourceTypes},
+		{"resource_pattern_type", parts[2], aclPatternTypes},
+		{"operation", parts[5], aclOperations},
+		{"permission_type", parts[6], aclPermissionTypes},
+	}
+	for _, field := range fields {
+		if !containsString(field.value, field.valid) {
+			return ACLResourceModel{}, fmt.Errorf("%q is not a valid %s, must be one of: %s", field.value, field.name, strings.Join(field.valid, ", "))
+		}
+	}
+
+	data := ACLResourceModel{
+		ID:             types.StringValue(id),
+		ResourceType:   types.StringValue(parts[0]),
+		ResourceName:   types.StringValue(parts[1]),
+		PatternType:    types.StringValue(parts[2]),
+		Principal:      types.StringValue(parts[3]),
+		Host:           types.StringValue(parts[4]),
+		Operation:      types.StringValue(parts[5]),
+		PermissionType: types.StringValue(parts[6]),
+	}
+	if _, err := data.toACLEntry(); err != nil {
+		return ACLResourceModel{}, err
+	}
+	return data, nil
+}
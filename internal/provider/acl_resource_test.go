@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccACLResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccACLResourceConfig("User:alice", "read"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_acl.test", "id", "topic|acl.test|literal|User:alice|*|read|allow"),
+					resource.TestCheckResourceAttr("kafka_acl.test", "resource_pattern_type", "literal"),
+					resource.TestCheckResourceAttr("kafka_acl.test", "host", "*"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "kafka_acl.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Replace and Read testing
+			{
+				Config: testAccACLResourceConfig("User:bob", "write"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_acl.test", "id", "topic|acl.test|literal|User:bob|*|write|allow"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccACLResourceConfig(principal string, operation string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_acl" "test" {
+  resource_type   = "topic"
+  resource_name   = "acl.test"
+  principal       = %[1]q
+  operation       = %[2]q
+  permission_type = "allow"
+}
+`, principal, operation)
+}
+
+func TestParseACLID(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := parseACLID("topic|orders.|prefixed|User:alice|*|read|allow")
+	assert.NoError(err)
+	assert.Equal("orders.", data.ResourceName.ValueString())
+	assert.Equal("User:alice", data.Principal.ValueString())
+
+	entry, err := data.toACLEntry()
+	assert.NoError(err)
+	assert.Equal(kafka.ResourceTypeTopic, entry.ResourceType)
+	assert.Equal(kafka.PatternTypePrefixed, entry.ResourcePatternType)
+	assert.Equal(kafka.ACLOperationTypeRead, entry.Operation)
+	assert.Equal(kafka.ACLPermissionTypeAllow, entry.PermissionType)
+	assert.Equal("topic|orders.|prefixed|User:alice|*|read|allow", data.id())
+
+	_, err = parseACLID("topic|orders.|prefixed|User:alice|*|read")
+	assert.Error(err, "Missing fields should fail to parse")
+
+	_, err = parseACLID("topic||literal|User:alice|*|read|allow")
+	assert.Error(err, "Empty fields should fail to parse")
+
+	_, err = parseACLID("topic|orders|literal|User:alice|*|reed|allow")
+	assert.Error(err, "Unknown operations should fail to parse")
+
+	invalidIDs := []string{
+		"Any|*|MATCH|User:*|*|any|any",
+		"any|orders|literal|User:alice|*|read|allow",
+		"topic|orders|match|User:alice|*|read|allow",
+		"topic|orders|literal|User:alice|*|any|allow",
+		"topic|orders|literal|User:alice|*|read|unknown",
+		"Topic|orders|literal|User:alice|*|read|allow",
+		"topic|orders|LITERAL|User:alice|*|read|allow",
+		"topic|orders|literal|User:alice|*|Read|allow",
+		"topic|orders|literal|User:alice|*|read|ALLOW",
+	}
+	for _, id := range invalidIDs {
+		_, err = parseACLID(id)
+		assert.Error(err, "%s should fail to parse", id)
+	}
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	signer "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/aws_msk_iam_v2"
+)
+
+// defaultAWSRoleSessionName is the session name used when assuming a role
+// without aws_role_session_name
+const defaultAWSRoleSessionName = "terraform-provider-kafka"
+
+// awsMSKIAMOptions are the AWS options of the aws-msk-iam mechanism
+type awsMSKIAMOptions struct {
+	Region          string
+	Profile         string
+	RoleARN         string
+	RoleSessionName string
+	ExternalID      string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// isSet returns whether any option is set. When none is, the ambient AWS
+// configuration is used through topicctl.
+func (o awsMSKIAMOptions) isSet() bool {
+	return o != awsMSKIAMOptions{}
+}
+
+// awsMSKIAMOptions returns the AWS options from the SASLConfigModel, falling
+// back to the environment
+func (p *kafkaProvider) awsMSKIAMOptions(saslConfig *SASLConfigModel) awsMSKIAMOptions {
+	return awsMSKIAMOptions{
+		Region:          p.getString(saslConfig.AWSRegion, "SASL_AWS_REGION", ""),
+		Profile:         p.getString(saslConfig.AWSProfile, "SASL_AWS_PROFILE", ""),
+		RoleARN:         p.getString(saslConfig.AWSRoleARN, "SASL_AWS_ROLE_ARN", ""),
+		RoleSessionName: p.getString(saslConfig.AWSRoleSessionName, "SASL_AWS_ROLE_SESSION_NAME", ""),
+		ExternalID:      p.getString(saslConfig.AWSExternalID, "SASL_AWS_EXTERNAL_ID", ""),
+		AccessKeyID:     p.getString(saslConfig.AWSAccessKeyID, "SASL_AWS_ACCESS_KEY_ID", ""),
+		SecretAccessKey: p.getString(saslConfig.AWSSecretAccessKey, "SASL_AWS_SECRET_ACCESS_KEY", ""),
+		SessionToken:    p.getString(saslConfig.AWSSessionToken, "SASL_AWS_SESSION_TOKEN", ""),
+	}
+}
+
+// awsMSKIAMMechanism returns the AWS_MSK_IAM mechanism signing with the
+// credentials resolved from the options
+func awsMSKIAMMechanism(ctx context.Context, options awsMSKIAMOptions) (sasl.Mechanism, error) {
+	loadOptions := []func(*awsconfig.LoadOptions) error{}
+	if options.Region != "" {
+		loadOptions = append(loadOptions, awsconfig.WithRegion(options.Region))
+	}
+	if options.Profile != "" {
+		loadOptions = append(loadOptions, awsconfig.WithSharedConfigProfile(options.Profile))
+	}
+	if (options.AccessKeyID == "") != (options.SecretAccessKey == "") {
+		return nil, errors.New("aws_access_key_id and aws_secret_access_key must be set together")
+	}
+	if options.AccessKeyID != "" {
+		loadOptions = append(loadOptions, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(options.AccessKeyID, options.SecretAccessKey, options.SessionToken),
+		))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, loadOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("the aws-msk-iam SASL mechanism requires an AWS region")
+	}
+
+	if options.RoleARN != "" {
+		sessionName := options.RoleSessionName
+		if sessionName == "" {
+			sessionName = defaultAWSRoleSessionName
+		}
+		roleProvider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), options.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = sessionName
+			if options.ExternalID != "" {
+				o.ExternalID = aws.String(options.ExternalID)
+			}
+		})
+		cfg.Credentials = aws.NewCredentialsCache(roleProvider)
+	}
+
+	return &aws_msk_iam_v2.Mechanism{
+		Signer:      signer.NewSigner(),
+		Credentials: cfg.Credentials,
+		Region:      cfg.Region,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/kafka-go/sasl/aws_msk_iam_v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// isolateAWSConfig keeps the tests from reading the AWS configuration of the
+// environment
+func isolateAWSConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	for _, key := range []string{"AWS_REGION", "AWS_DEFAULT_REGION", "AWS_PROFILE", "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestGenerateSASLConfigAWSMSKIAM(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	p := &kafkaProvider{typeName: "kafka"}
+	unsetSASLEnv(t)
+	isolateAWSConfig(t)
+
+	// Without options, topicctl uses the ambient AWS configuration
+	config, mechanism, err := p.generateSASLConfig(ctx, &SASLConfigModel{Mechanism: types.StringValue("aws-msk-iam")})
+	assert.NoError(err)
+	assert.True(config.Enabled)
+	assert.Nil(mechanism)
+
+	// Static credentials
+	config, mechanism, err = p.generateSASLConfig(ctx, &SASLConfigModel{
+		Mechanism:          types.StringValue("aws-msk-iam"),
+		AWSRegion:          types.StringValue("eu-west-1"),
+		AWSAccessKeyID:     types.StringValue("AKID"),
+		AWSSecretAccessKey: types.StringValue("SECRET"),
+		AWSSessionToken:    types.StringValue("TOKEN"),
+	})
+	assert.NoError(err)
+	assert.False(config.Enabled, "The mechanism should be set on the clients instead")
+	mskMechanism := mechanism.(*aws_msk_iam_v2.Mechanism)
+	assert.Equal("eu-west-1", mskMechanism.Region)
+	credentials, err := mskMechanism.Credentials.Retrieve(ctx)
+	assert.NoError(err)
+	assert.Equal("AKID", credentials.AccessKeyID)
+	assert.Equal("SECRET", credentials.SecretAccessKey)
+	assert.Equal("TOKEN", credentials.SessionToken)
+
+	// Assumed role, with the options from the environment
+	t.Setenv("KAFKA_SASL_MECHANISM", "aws-msk-iam")
+	t.Setenv("KAFKA_SASL_AWS_REGION", "us-east-1")
+	t.Setenv("KAFKA_SASL_AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/kafka-admin")
+	t.Setenv("KAFKA_SASL_AWS_EXTERNAL_ID", "external")
+	_, mechanism, err = p.generateSASLConfig(ctx, nil)
+	assert.NoError(err)
+	mskMechanism = mechanism.(*aws_msk_iam_v2.Mechanism)
+	assert.Equal("us-east-1", mskMechanism.Region)
+	assert.IsType(&aws.CredentialsCache{}, mskMechanism.Credentials)
+}
+
+func TestAWSMSKIAMMechanismErrors(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	isolateAWSConfig(t)
+
+	_, err := awsMSKIAMMechanism(ctx, awsMSKIAMOptions{RoleARN: "arn:aws:iam::123456789012:role/kafka-admin"})
+	assert.ErrorContains(err, "region", "A region should be required")
+
+	_, err = awsMSKIAMMechanism(ctx, awsMSKIAMOptions{Region: "eu-west-1", AccessKeyID: "AKID"})
+	assert.ErrorContains(err, "aws_secret_access_key", "Static credentials should be complete")
+
+	_, err = awsMSKIAMMechanism(ctx, awsMSKIAMOptions{Region: "eu-west-1", Profile: "missing"})
+	assert.Error(err, "Missing profiles should fail")
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &brokersDataSource{}
+
+func NewBrokersDataSource() datasource.DataSource {
+	return &brokersDataSource{}
+}
+
+// brokersDataSource defines the data source implementation.
+type brokersDataSource struct {
+	client *admin.BrokerAdminClient
+}
+
+// brokersDataSourceModel describes the data source data model.
+type brokersDataSourceModel struct {
+	ID      types.String              `tfsdk:"id"`
+	IDs     types.List                `tfsdk:"ids"`
+	Brokers []brokersDataSourceBroker `tfsdk:"brokers"`
+}
+
+// brokersDataSourceBroker describes each broker of the data source.
+type brokersDataSourceBroker struct {
+	ID     types.Int64  `tfsdk:"id"`
+	Host   types.String `tfsdk:"host"`
+	Port   types.Int64  `tfsdk:"port"`
+	Rack   types.String `tfsdk:"rack"`
+	Config types.Map    `tfsdk:"configuration"`
+}
+
+func (d *brokersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_brokers"
+}
+
+func (d *brokersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Brokers data source",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "Only include the brokers with these IDs, which must be in the cluster. When unset, all the brokers are included",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+			},
+			"brokers": schema.ListNestedAttribute{
+				MarkdownDescription: "Brokers, sorted by ID",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Broker ID",
+							Computed:            true,
+						},
+						"host": schema.StringAttribute{
+							MarkdownDescription: "Broker host",
+							Computed:            true,
+						},
+						"port": schema.Int64Attribute{
+							MarkdownDescription: "Broker port",
+							Computed:            true,
+						},
+						"rack": schema.StringAttribute{
+							MarkdownDescription: "Broker rack, empty when the broker has no rack",
+							Computed:            true,
+						},
+						"configuration": schema.MapAttribute{
+							MarkdownDescription: "Broker configuration, without the default and static values",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *brokersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.BrokerAdminClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.BrokerAdminClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *brokersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data brokersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.IDs.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ids"),
+			"Unknown broker IDs",
+			"The data source cannot read the brokers as there is an unknown value for the broker IDs. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+		return
+	}
+	configIDs := []int64{}
+	if !data.IDs.IsNull() {
+		resp.Diagnostics.Append(data.IDs.ElementsAs(ctx, &configIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	ids := []int{}
+	for _, id := range configIDs {
+		ids = append(ids, int(id))
+	}
+
+	brokerInfos, err := d.client.GetBrokers(ctx, ids)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read brokers, got error: %s", err))
+		return
+	}
+	sort.Slice(brokerInfos, func(i, j int) bool { return brokerInfos[i].ID < brokerInfos[j].ID })
+
+	// The brokers that are not in the cluster are left out of the response
+	found := map[int]bool{}
+	for _, brokerInfo := range brokerInfos {
+		found[brokerInfo.ID] = true
+	}
+	for i, id := range ids {
+		if !found[id] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ids").AtListIndex(i),
+				"Unknown broker",
+				fmt.Sprintf("Broker %d is not in the cluster", id),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	brokers := []brokersDataSourceBroker{}
+	brokerIDs := []string{}
+	for _, brokerInfo := range brokerInfos {
+		configElement := make(map[string]attr.Value)
+		for k, v := range brokerInfo.Config {
+			configElement[k] = types.StringValue(v)
+		}
+		brokers = append(brokers, brokersDataSourceBroker{
+			ID:     types.Int64Value(int64(brokerInfo.ID)),
+			Host:   types.StringValue(brokerInfo.Host),
+			Port:   types.Int64Value(int64(brokerInfo.Port)),
+			Rack:   types.StringValue(brokerInfo.Rack),
+			Config: types.MapValueMust(types.StringType, configElement),
+		})
+		brokerIDs = append(brokerIDs, strconv.Itoa(brokerInfo.ID))
+	}
+
+	data.ID = types.StringValue(strings.Join(brokerIDs, ","))
+	data.Brokers = brokers
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
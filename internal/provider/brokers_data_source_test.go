@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBrokersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "kafka_brokers" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kafka_brokers.test", "brokers.#", "1"),
+					resource.TestCheckResourceAttr("data.kafka_brokers.test", "brokers.0.id", "1"),
+					resource.TestCheckResourceAttrSet("data.kafka_brokers.test", "brokers.0.host"),
+					resource.TestCheckResourceAttrSet("data.kafka_brokers.test", "brokers.0.port"),
+				),
+			},
+			// Filter by ID
+			{
+				Config: providerConfig + `
+data "kafka_brokers" "test" {
+  ids = [1]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kafka_brokers.test", "brokers.#", "1"),
+					resource.TestCheckResourceAttr("data.kafka_brokers.test", "brokers.0.id", "1"),
+				),
+			},
+			// Brokers missing from the cluster fail
+			{
+				Config: providerConfig + `
+data "kafka_brokers" "test" {
+  ids = [1, 2]
+}
+`,
+				ExpectError: regexp.MustCompile("Broker 2 is not in the cluster"),
+			},
+		},
+	})
+}
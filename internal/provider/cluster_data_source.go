@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &clusterDataSource{}
+
+func NewClusterDataSource() datasource.DataSource {
+	return &clusterDataSource{}
+}
+
+// clusterDataSource defines the data source implementation.
+type clusterDataSource struct {
+	client *admin.BrokerAdminClient
+}
+
+// clusterDataSourceModel describes the data source data model.
+type clusterDataSourceModel struct {
+	ID           types.String  `tfsdk:"id"`
+	ControllerID types.Int64   `tfsdk:"controller_id"`
+	BrokerIDs    []types.Int64 `tfsdk:"broker_ids"`
+}
+
+func (d *clusterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster"
+}
+
+func (d *clusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Cluster data source",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Cluster ID",
+				Computed:            true,
+			},
+			"controller_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the controller broker",
+				Computed:            true,
+			},
+			"broker_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the brokers in the cluster, sorted",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *clusterDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.BrokerAdminClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.BrokerAdminClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *clusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data clusterDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := d.client.GetClusterID(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster ID, got error: %s", err))
+		return
+	}
+
+	controllerID, err := d.client.GetControllerID(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read controller ID, got error: %s", err))
+		return
+	}
+
+	brokerIDs, err := d.client.GetBrokerIDs(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read broker IDs, got error: %s", err))
+		return
+	}
+	sort.Ints(brokerIDs)
+
+	data.ID = types.StringValue(clusterID)
+	data.ControllerID = types.Int64Value(int64(controllerID))
+	data.BrokerIDs = []types.Int64{}
+	for _, brokerID := range brokerIDs {
+		data.BrokerIDs = append(data.BrokerIDs, types.Int64Value(int64(brokerID)))
+	}
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
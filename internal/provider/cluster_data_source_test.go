@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccClusterDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "kafka_cluster" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kafka_cluster.test", "id"),
+					resource.TestCheckResourceAttr("data.kafka_cluster.test", "controller_id", "1"),
+					resource.TestCheckResourceAttr("data.kafka_cluster.test", "broker_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.kafka_cluster.test", "broker_ids.0", "1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/apiversions"
+	"github.com/segmentio/kafka-go/protocol/describeconfigs"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// fakeBrokerAPIs are the APIs advertised by the fake broker
+var fakeBrokerAPIs = []protocol.ApiKey{
+	protocol.ApiVersions,
+	protocol.Metadata,
+	protocol.DescribeConfigs,
+	protocol.IncrementalAlterConfigs,
+	protocol.AlterPartitionReassignments,
+	protocol.ListPartitionReassignments,
+	protocol.ElectLeaders,
+}
+
+// fakeBroker is a minimal Kafka broker for the tests that need a client. It
+// answers ApiVersions, Metadata and DescribeConfigs, advertising brokers 1 to
+// 3 on its own address with the configs set with setConfigs and the API
+// versions limited with setMaxVersion, and records every other request before
+// passing it to the handler.
+type fakeBroker struct {
+	listener net.Listener
+	handler  func(protocol.Message) (protocol.Message, error)
+	topics   []metadata.ResponseTopic
+
+	mu          sync.Mutex
+	requests    []protocol.Message
+	configs     map[string][]describeconfigs.ResponseConfigEntry
+	maxVersions map[protocol.ApiKey]int16
+}
+
+// newFakeBroker starts a fake broker that is stopped when the test ends
+func newFakeBroker(t *testing.T, topics []metadata.ResponseTopic, handler func(protocol.Message) (protocol.Message, error)) *fakeBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	broker := &fakeBroker{listener: listener, handler: handler, topics: topics}
+	t.Cleanup(func() { listener.Close() })
+	go broker.serve()
+	return broker
+}
+
+// client returns an admin client connected to the fake broker
+func (b *fakeBroker) client(t *testing.T) *admin.BrokerAdminClient {
+	client, err := admin.NewBrokerAdminClient(context.Background(), admin.BrokerAdminClientConfig{
+		ConnectorConfig: admin.ConnectorConfig{BrokerAddr: b.listener.Addr().String()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// setConfigs sets the configs described for each resource name
+func (b *fakeBroker) setConfigs(configs map[string][]describeconfigs.ResponseConfigEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.configs = configs
+}
+
+// setMaxVersion limits the versions advertised for an API
+func (b *fakeBroker) setMaxVersion(apiKey protocol.ApiKey, version int16) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.maxVersions == nil {
+		b.maxVersions = map[protocol.ApiKey]int16{}
+	}
+	b.maxVersions[apiKey] = version
+}
+
+// received returns the requests passed to the handler, in order
+func (b *fakeBroker) received() []protocol.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]protocol.Message{}, b.requests...)
+}
+
+func (b *fakeBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.serveConn(conn)
+	}
+}
+
+func (b *fakeBroker) serveConn(conn net.Conn) {
+	defer conn.Close()
+	for {
+		apiVersion, correlationID, _, req, err := protocol.ReadRequest(conn)
+		if err != nil {
+			return
+		}
+		resp, err := b.respond(req)
+		if err != nil {
+			// Closing the connection fails the request
+			return
+		}
+		if err := protocol.WriteResponse(conn, apiVersion, correlationID, resp); err != nil {
+			return
+		}
+	}
+}
+
+func (b *fakeBroker) respond(req protocol.Message) (protocol.Message, error) {
+	switch r := req.(type) {
+	case *apiversions.Request:
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		apiKeys := []apiversions.ApiKeyResponse{}
+		for _, apiKey := range fakeBrokerAPIs {
+			maxVersion, ok := b.maxVersions[apiKey]
+			if !ok {
+				maxVersion = apiKey.MaxVersion()
+			}
+			apiKeys = append(apiKeys, apiversions.ApiKeyResponse{
+				ApiKey:     int16(apiKey),
+				MinVersion: apiKey.MinVersion(),
+				MaxVersion: maxVersion,
+			})
+		}
+		return &apiversions.Response{ApiKeys: apiKeys}, nil
+	case *metadata.Request:
+		host, port, _ := net.SplitHostPort(b.listener.Addr().String())
+		portNumber, _ := strconv.Atoi(port)
+		brokers := []metadata.ResponseBroker{}
+		for id := int32(1); id <= 3; id++ {
+			brokers = append(brokers, metadata.ResponseBroker{NodeID: id, Host: host, Port: int32(portNumber)})
+		}
+		return &metadata.Response{Brokers: brokers, ControllerID: 1, Topics: b.topics}, nil
+	case *describeconfigs.Request:
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		resources := []describeconfigs.ResponseResource{}
+		for _, resource := range r.Resources {
+			resources = append(resources, describeconfigs.ResponseResource{
+				ResourceType:  resource.ResourceType,
+				ResourceName:  resource.ResourceName,
+				ConfigEntries: b.configs[resource.ResourceName],
+			})
+		}
+		return &describeconfigs.Response{Resources: resources}, nil
+	}
+
+	b.mu.Lock()
+	b.requests = append(b.requests, req)
+	b.mu.Unlock()
+	if b.handler == nil {
+		return nil, errors.New("unexpected request")
+	}
+	return b.handler(req)
+}
@@ -0,0 +1,371 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/electleaders"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                   = &leaderElectionResource{}
+	_ resource.ResourceWithConfigure      = &leaderElectionResource{}
+	_ resource.ResourceWithValidateConfig = &leaderElectionResource{}
+)
+
+const (
+	electionTypePreferred = "preferred"
+	electionTypeUnclean   = "unclean"
+)
+
+// electLeadersElectionTypeVersion is the first ElectLeaders API version that
+// sends the election type
+const electLeadersElectionTypeVersion = 1
+
+// electionTypes are the valid values of election_type, in the order of their
+// ElectLeaders API value
+var electionTypes = []string{electionTypePreferred, electionTypeUnclean}
+
+func NewLeaderElectionResource() resource.Resource {
+	return &leaderElectionResource{}
+}
+
+// leaderElectionResource defines the resource implementation.
+type leaderElectionResource struct {
+	client *admin.BrokerAdminClient
+}
+
+// LeaderElectionResourceModel describes the resource data model.
+type LeaderElectionResourceModel struct {
+	ID                types.String  `tfsdk:"id"`
+	Topic             types.String  `tfsdk:"topic"`
+	Partitions        []types.Int64 `tfsdk:"partitions"`
+	ElectionType      types.String  `tfsdk:"election_type"`
+	Triggers          types.Map     `tfsdk:"triggers"`
+	ElectedPartitions []types.Int64 `tfsdk:"elected_partitions"`
+}
+
+// electionType returns the configured election type, or the default one
+func (m *LeaderElectionResourceModel) electionType() string {
+	if m.ElectionType.IsNull() {
+		return electionTypePreferred
+	}
+	return m.ElectionType.ValueString()
+}
+
+func (r *leaderElectionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_leader_election"
+}
+
+func (r *leaderElectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a leader election for the partitions of a topic when the resource is created, or replaced when any of its attributes change. " +
+			"Destroying the resource has no effect on the cluster",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Leader election id",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"topic": schema.StringAttribute{
+				MarkdownDescription: "Topic name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"partitions": schema.ListAttribute{
+				MarkdownDescription: "Partitions to elect a leader for, each included once. When unset, every partition of the topic is included",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"election_type": schema.StringAttribute{
+				MarkdownDescription: "Election type, one of: `" + strings.Join(electionTypes, "`, `") + "`. " +
+					"`preferred` moves the leadership back to the first replica of each partition. " +
+					"`unclean` elects an out of sync replica when no in-sync replica is available, which can lose data and requires Kafka 2.4 or later (default: preferred)",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that run a new election when they change, such as the topic `replica_assignment`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"elected_partitions": schema.ListAttribute{
+				MarkdownDescription: "Partitions whose leader was elected. Partitions already led by the elected replica are not included",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *leaderElectionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*kafkaResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *kafkaResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *leaderElectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data LeaderElectionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ElectionType.IsNull() && !data.ElectionType.IsUnknown() &&
+		!containsString(data.ElectionType.ValueString(), electionTypes) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("election_type"),
+			"Invalid election type",
+			fmt.Sprintf("%q is not a valid election type, must be one of: %s", data.ElectionType.ValueString(), strings.Join(electionTypes, ", ")),
+		)
+	}
+
+	seen := map[int64]bool{}
+	for i, partition := range data.Partitions {
+		if partition.IsUnknown() || partition.IsNull() {
+			continue
+		}
+		switch {
+		case partition.ValueInt64() < 0:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("partitions").AtListIndex(i),
+				"Invalid partition",
+				fmt.Sprintf("%d is not a valid partition, must be 0 or greater", partition.ValueInt64()),
+			)
+		case seen[partition.ValueInt64()]:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("partitions").AtListIndex(i),
+				"Invalid partition",
+				fmt.Sprintf("Partition %d is included more than once", partition.ValueInt64()),
+			)
+		}
+		seen[partition.ValueInt64()] = true
+	}
+}
+
+func (r *leaderElectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *LeaderElectionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	partitions := []int{}
+	for _, partition := range data.Partitions {
+		partitions = append(partitions, int(partition.ValueInt64()))
+	}
+	// An empty request elects the leaders of every topic, so we list the
+	// partitions of this one
+	if len(partitions) == 0 {
+		topicInfo, err := r.client.GetTopic(ctx, data.Topic.ValueString(), false)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read topic, got error: %s", err))
+			return
+		}
+		for _, partition := range topicInfo.Partitions {
+			partitions = append(partitions, partition.ID)
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Running %s leader election for topic %s partitions %v", data.electionType(), data.Topic.ValueString(), partitions))
+	elected, err := electLeaders(ctx, r.client, data.Topic.ValueString(), partitions, data.electionType())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to elect leaders, got error: %s", err))
+		return
+	}
+
+	data.ID = data.Topic
+	data.ElectedPartitions = []types.Int64{}
+	for _, partition := range elected {
+		data.ElectedPartitions = append(data.ElectedPartitions, types.Int64Value(int64(partition)))
+	}
+	tflog.Trace(ctx, "Elected leaders")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *leaderElectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *LeaderElectionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The election is a one-off operation, we only check the topic still
+	// exists so a recreated topic gets a new election
+	_, err := r.client.GetTopic(ctx, data.Topic.ValueString(), false)
+	if err != nil {
+		switch err {
+		case admin.ErrTopicDoesNotExist:
+			resp.State.RemoveResource(ctx)
+			return
+		default:
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read topic, got error: %s", err))
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *leaderElectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update
+	var data *LeaderElectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *leaderElectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The election can't be undone, the resource is only removed from state
+	tflog.Trace(ctx, "Removed leader election")
+}
+
+// electLeaders runs a leader election for the given topic partitions, and
+// returns the partitions whose leader was elected.
+// kafka-go ElectLeaders only runs preferred elections, so we send the request
+// through the transport directly to set the election type.
+func electLeaders(ctx context.Context, client *admin.BrokerAdminClient, topic string, partitions []int, electionType string) ([]int, error) {
+	apiPartitions := []int32{}
+	for _, partition := range partitions {
+		apiPartitions = append(apiPartitions, int32(partition))
+	}
+
+	kafkaClient := client.GetConnector().KafkaClient
+	// Older versions don't send the election type and run a preferred
+	// election instead
+	if electionType == electionTypeUnclean {
+		supported, err := supportsElectionType(ctx, kafkaClient)
+		if err != nil {
+			return nil, err
+		}
+		if !supported {
+			return nil, fmt.Errorf("the brokers don't support %s elections, which require ElectLeaders v%d (Kafka 2.4 or later)", electionType, electLeadersElectionTypeVersion)
+		}
+	}
+
+	transport := kafkaClient.Transport
+	if transport == nil {
+		transport = kafka.DefaultTransport
+	}
+	protoResp, err := transport.RoundTrip(ctx, kafkaClient.Addr, &electleaders.Request{
+		ElectionType: electionTypeValue(electionType),
+		TopicPartitions: []electleaders.RequestTopicPartitions{
+			{
+				Topic:        topic,
+				PartitionIDs: apiPartitions,
+			},
+		},
+		TimeoutMs: int32(kafkaClient.Timeout.Milliseconds()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiResp := protoResp.(*electleaders.Response)
+	if apiResp.ErrorCode != 0 {
+		return nil, kafka.Error(apiResp.ErrorCode)
+	}
+	elected := []int{}
+	partErrors := []error{}
+	for _, result := range apiResp.ReplicaElectionResults {
+		for _, p := range result.PartitionResults {
+			switch {
+			case p.ErrorCode == 0:
+				elected = append(elected, int(p.PartitionID))
+			case kafka.Error(p.ErrorCode) == kafka.ElectionNotNeeded:
+				// The partition is already led by the elected replica
+			default:
+				partErrors = append(partErrors, fmt.Errorf("partition %d: %w", p.PartitionID, kafka.Error(p.ErrorCode)))
+			}
+		}
+	}
+	if len(partErrors) > 0 {
+		return nil, fmt.Errorf("errors electing leaders: %s", partErrors)
+	}
+	sort.Ints(elected)
+	return elected, nil
+}
+
+// supportsElectionType returns whether the broker supports the ElectLeaders
+// version that sends the election type
+func supportsElectionType(ctx context.Context, kafkaClient *kafka.Client) (bool, error) {
+	apiVersions, err := kafkaClient.ApiVersions(ctx, &kafka.ApiVersionsRequest{Addr: kafkaClient.Addr})
+	if err != nil {
+		return false, err
+	}
+	if apiVersions.Error != nil {
+		return false, apiVersions.Error
+	}
+	for _, apiKey := range apiVersions.ApiKeys {
+		if apiKey.ApiKey == int(protocol.ElectLeaders) {
+			return apiKey.MaxVersion >= electLeadersElectionTypeVersion, nil
+		}
+	}
+	return false, nil
+}
+
+// electionTypeValue returns the ElectLeaders API value of the election type
+func electionTypeValue(electionType string) int8 {
+	if electionType == electionTypeUnclean {
+		return 1
+	}
+	return 0
+}
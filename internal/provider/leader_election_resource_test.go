@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/electleaders"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccLeaderElectionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccLeaderElectionResourceConfig("election", "first", `election_type = "clean"`),
+				ExpectError: regexp.MustCompile("Invalid election type"),
+			},
+			{
+				Config:      testAccLeaderElectionResourceConfig("election", "first", `partitions = [-1]`),
+				ExpectError: regexp.MustCompile("Invalid partition"),
+			},
+			{
+				Config:      testAccLeaderElectionResourceConfig("election", "first", `partitions = [0, 1, 0]`),
+				ExpectError: regexp.MustCompile("Invalid partition"),
+			},
+			// The single broker already leads every partition, so no
+			// election is needed
+			{
+				Config: testAccLeaderElectionResourceConfig("election", "first", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_leader_election.test", "id", "election"),
+					resource.TestCheckResourceAttr("kafka_leader_election.test", "elected_partitions.#", "0"),
+				),
+			},
+			// Changing the triggers runs a new election
+			{
+				Config: testAccLeaderElectionResourceConfig("election", "second", `partitions = [0, 1]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_leader_election.test", "triggers.run", "second"),
+					resource.TestCheckResourceAttr("kafka_leader_election.test", "partitions.#", "2"),
+					resource.TestCheckResourceAttr("kafka_leader_election.test", "elected_partitions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestElectionTypeValue(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(int8(0), electionTypeValue(electionTypePreferred))
+	assert.Equal(int8(1), electionTypeValue(electionTypeUnclean))
+}
+
+func TestElectLeaders(t *testing.T) {
+	// Partition 0 is elected, while partition 1 already has the elected leader
+	handler := func(req protocol.Message) (protocol.Message, error) {
+		return &electleaders.Response{
+			ReplicaElectionResults: []electleaders.ResponseReplicaElectionResult{
+				{Topic: "test", PartitionResults: []electleaders.ResponsePartitionResult{
+					{PartitionID: 0},
+					{PartitionID: 1, ErrorCode: int16(kafka.ElectionNotNeeded)},
+				}},
+			},
+		}, nil
+	}
+
+	testCases := []struct {
+		name         string
+		electionType string
+		maxVersion   int16
+		requests     []*electleaders.Request
+		err          string
+	}{
+		{
+			name:         "unclean election",
+			electionType: electionTypeUnclean,
+			maxVersion:   1,
+			requests: []*electleaders.Request{
+				{ElectionType: 1, TopicPartitions: []electleaders.RequestTopicPartitions{{Topic: "test", PartitionIDs: []int32{0, 1}}}},
+			},
+		},
+		{
+			name:         "preferred election without election type",
+			electionType: electionTypePreferred,
+			maxVersion:   0,
+			requests: []*electleaders.Request{
+				{ElectionType: 0, TopicPartitions: []electleaders.RequestTopicPartitions{{Topic: "test", PartitionIDs: []int32{0, 1}}}},
+			},
+		},
+		{
+			name:         "unclean election without election type",
+			electionType: electionTypeUnclean,
+			maxVersion:   0,
+			requests:     []*electleaders.Request{},
+			err:          "don't support unclean elections",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			broker := newFakeBroker(t, nil, handler)
+			broker.setMaxVersion(protocol.ElectLeaders, tc.maxVersion)
+			elected, err := electLeaders(context.Background(), broker.client(t), "test", []int{0, 1}, tc.electionType)
+			if tc.err != "" {
+				assert.ErrorContains(err, tc.err)
+			} else {
+				assert.NoError(err)
+				assert.Equal([]int{0}, elected)
+			}
+			assert.Equal(tc.requests, requestsOf[*electleaders.Request](broker.received()))
+		})
+	}
+}
+
+func testAccLeaderElectionResourceConfig(topic string, run string, extra string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_topic" "test" {
+  name = %[1]q
+  partitions = 3
+  replication_factor = 1
+}
+
+resource "kafka_leader_election" "test" {
+  topic = kafka_topic.test.name
+  triggers = {
+    run = %[2]q
+  }
+  %[3]s
+}
+`, topic, run, extra)
+}
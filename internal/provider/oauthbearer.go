@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go/sasl"
+)
+
+// oauthTokenRefreshMargin is how long before its expiry a token is refreshed,
+// so it doesn't expire while a connection authenticates
+var oauthTokenRefreshMargin = time.Minute
+
+// oauthTokenSource returns the tokens used by the OAUTHBEARER mechanism
+type oauthTokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// staticTokenSource always returns the same token
+type staticTokenSource string
+
+func (s staticTokenSource) Token(_ context.Context) (string, error) {
+	return string(s), nil
+}
+
+// clientCredentialsTokenSource fetches tokens from an OAuth token endpoint
+// using the client credentials grant, caching them until they are about to
+// expire
+type clientCredentialsTokenSource struct {
+	TokenEndpoint string
+	ClientID      string
+	ClientSecret  string
+	Scopes        []string
+	HTTPClient    *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// oauthTokenResponse is the token endpoint response, as described in RFC 6749
+// section 5.1
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (s *clientCredentialsTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Add(oauthTokenRefreshMargin).Before(s.expiry) {
+		return s.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", s.ClientID)
+	form.Set("client_secret", s.ClientSecret)
+	if len(s.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	httpResp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to fetch OAuth token: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to read OAuth token response: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to fetch OAuth token, got status %d: %s", httpResp.StatusCode, body)
+	}
+
+	var tokenResp oauthTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("unable to parse OAuth token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return "", errors.New("OAuth token response has no access_token")
+	}
+
+	// Without an expiry the token is fetched again for every new connection
+	s.token = tokenResp.AccessToken
+	s.expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	return s.token, nil
+}
+
+// oauthBearerMechanism implements the OAUTHBEARER SASL mechanism described in
+// RFC 7628. A token is requested every time a connection authenticates, so
+// new connections get a fresh token during long operations.
+type oauthBearerMechanism struct {
+	TokenSource oauthTokenSource
+}
+
+var _ sasl.Mechanism = (*oauthBearerMechanism)(nil)
+
+func (m *oauthBearerMechanism) Name() string {
+	return "OAUTHBEARER"
+}
+
+func (m *oauthBearerMechanism) Start(ctx context.Context) (sasl.StateMachine, []byte, error) {
+	token, err := m.TokenSource.Token(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, []byte("n,,\x01auth=Bearer " + token + "\x01\x01"), nil
+}
+
+func (m *oauthBearerMechanism) Next(_ context.Context, challenge []byte) (bool, []byte, error) {
+	// The server only sends a challenge when the authentication fails, with
+	// the error details
+	if len(challenge) > 0 {
+		return false, nil, fmt.Errorf("OAUTHBEARER authentication failed: %s", challenge)
+	}
+	return true, nil, nil
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTokenEndpoint returns a stub OAuth token endpoint issuing numbered tokens
+func newTokenEndpoint(t *testing.T, expiresIn int) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		if r.PostForm.Get("grant_type") != "client_credentials" ||
+			r.PostForm.Get("client_id") != "app" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client"}`)
+			return
+		}
+		assert.Equal(t, "kafka.read kafka.write", r.PostForm.Get("scope"))
+
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, requests, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestClientCredentialsTokenSource(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	server, requests := newTokenEndpoint(t, 3600)
+	source := &clientCredentialsTokenSource{
+		TokenEndpoint: server.URL,
+		ClientID:      "app",
+		ClientSecret:  "secret",
+		Scopes:        []string{"kafka.read", "kafka.write"},
+	}
+
+	token, err := source.Token(ctx)
+	assert.NoError(err)
+	assert.Equal("token-1", token)
+	token, err = source.Token(ctx)
+	assert.NoError(err)
+	assert.Equal("token-1", token, "Valid tokens should be cached")
+	assert.Equal(1, *requests)
+
+	// Tokens expiring within the refresh margin are refreshed
+	server, requests = newTokenEndpoint(t, 30)
+	source.TokenEndpoint = server.URL
+	source.expiry = time.Now().Add(30 * time.Second)
+	token, err = source.Token(ctx)
+	assert.NoError(err)
+	assert.Equal("token-1", token)
+	token, err = source.Token(ctx)
+	assert.NoError(err)
+	assert.Equal("token-2", token, "Tokens expiring within the refresh margin should be refreshed")
+	assert.Equal(2, *requests)
+
+	source.ClientSecret = "wrong"
+	source.token = ""
+	_, err = source.Token(ctx)
+	assert.ErrorContains(err, "invalid_client")
+}
+
+func TestOAuthBearerMechanism(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	mechanism := &oauthBearerMechanism{TokenSource: staticTokenSource("abc")}
+	assert.Equal("OAUTHBEARER", mechanism.Name())
+
+	state, ir, err := mechanism.Start(ctx)
+	assert.NoError(err)
+	assert.Equal([]byte("n,,\x01auth=Bearer abc\x01\x01"), ir)
+
+	done, _, err := state.Next(ctx, nil)
+	assert.NoError(err)
+	assert.True(done)
+
+	_, _, err = state.Next(ctx, []byte(`{"status":"invalid_token"}`))
+	assert.ErrorContains(err, "invalid_token")
+}
+
+func TestGenerateSASLConfigOAuthBearer(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	p := &kafkaProvider{typeName: "kafka"}
+	unsetSASLEnv(t)
+
+	server, _ := newTokenEndpoint(t, 3600)
+	config, mechanism, err := p.generateSASLConfig(ctx, &SASLConfigModel{
+		Mechanism:     types.StringValue("oauthbearer"),
+		TokenEndpoint: types.StringValue(server.URL),
+		ClientID:      types.StringValue("app"),
+		ClientSecret:  types.StringValue("secret"),
+		Scopes:        types.ListValueMust(types.StringType, []attr.Value{types.StringValue("kafka.read"), types.StringValue("kafka.write")}),
+	})
+	assert.NoError(err)
+	assert.False(config.Enabled, "topicctl doesn't support OAUTHBEARER")
+	_, ir, err := mechanism.Start(ctx)
+	assert.NoError(err)
+	assert.Contains(string(ir), "auth=Bearer token-1")
+
+	t.Setenv("KAFKA_SASL_MECHANISM", "OAUTHBEARER")
+	t.Setenv("KAFKA_SASL_TOKEN", "static")
+	_, mechanism, err = p.generateSASLConfig(ctx, nil)
+	assert.NoError(err)
+	_, ir, err = mechanism.Start(ctx)
+	assert.NoError(err)
+	assert.Contains(string(ir), "auth=Bearer static")
+
+	t.Setenv("KAFKA_SASL_TOKEN", "")
+	t.Setenv("KAFKA_SASL_TOKEN_ENDPOINT", server.URL)
+	_, _, err = p.generateSASLConfig(ctx, nil)
+	assert.ErrorContains(err, "client_id, client_secret", "Missing client credentials should fail")
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &partitionForKeyFunction{}
+
+// partitioners are the balancers used by the producers to pick the partition
+// for a key
+var partitioners = map[string]kafka.Balancer{
+	// Java client default partitioner
+	"murmur2": kafka.Murmur2Balancer{Consistent: true},
+	// librdkafka default partitioner
+	"crc32": kafka.CRC32Balancer{Consistent: true},
+	// Sarama default partitioner
+	"fnv1a": &kafka.Hash{},
+}
+
+var partitionerNames = []string{"murmur2", "crc32", "fnv1a"}
+
+// maxPartitionsForKey bounds the partitions count, as the partitioners pick
+// the partition from a list with every partition of the topic
+const maxPartitionsForKey = 1000000
+
+func NewPartitionForKeyFunction() function.Function {
+	return &partitionForKeyFunction{}
+}
+
+// partitionForKeyFunction defines the function implementation.
+type partitionForKeyFunction struct{}
+
+func (f *partitionForKeyFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "partition_for_key"
+}
+
+func (f *partitionForKeyFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Partition for a message key",
+		MarkdownDescription: "Returns the partition a producer writes a message with the given key to, for a topic with the given number of partitions.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "key",
+				MarkdownDescription: "Message key",
+			},
+			function.Int64Parameter{
+				Name:                "partitions",
+				MarkdownDescription: fmt.Sprintf("Topic partitions count, at most %d", maxPartitionsForKey),
+			},
+			function.StringParameter{
+				Name: "partitioner",
+				MarkdownDescription: "Hash used by the producer partitioner, one of: `" + strings.Join(partitionerNames, "`, `") + "`. " +
+					"`murmur2` is the Java client default, `crc32` the librdkafka default and `fnv1a` the Sarama default",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *partitionForKeyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var key string
+	var partitions int64
+	var partitioner string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &key, &partitions, &partitioner))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validatePartitionsCount(partitions); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	partition, err := partitionForKey(key, int(partitions), partitioner)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, int64(partition)))
+}
+
+// partitionForKey returns the partition for the key using the given
+// partitioner
+func partitionForKey(key string, partitions int, partitioner string) (int, error) {
+	if err := validatePartitionsCount(int64(partitions)); err != nil {
+		return 0, err
+	}
+	balancer, ok := partitioners[partitioner]
+	if !ok {
+		return 0, fmt.Errorf("%q is not a valid partitioner, must be one of: %s", partitioner, strings.Join(partitionerNames, ", "))
+	}
+
+	partitionIDs := make([]int, partitions)
+	for i := range partitionIDs {
+		partitionIDs[i] = i
+	}
+	return balancer.Balance(kafka.Message{Key: []byte(key)}, partitionIDs...), nil
+}
+
+// validatePartitionsCount returns an error when the partitions count is out of
+// the accepted range
+func validatePartitionsCount(partitions int64) error {
+	if partitions < 1 || partitions > maxPartitionsForKey {
+		return fmt.Errorf("partitions must be between 1 and %d, got %d", maxPartitionsForKey, partitions)
+	}
+	return nil
+}
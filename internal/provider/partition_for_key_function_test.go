@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccPartitionForKeyFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "partition" {
+  value = provider::kafka::partition_for_key("abc", 1000, "murmur2")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("partition", "107"),
+				),
+			},
+			{
+				Config: `
+output "partition" {
+  value = provider::kafka::partition_for_key("abc", 10, "md5")
+}
+`,
+				ExpectError: regexp.MustCompile("not a valid partitioner"),
+			},
+			{
+				Config: `
+output "partition" {
+  value = provider::kafka::partition_for_key("abc", 10000000000, "murmur2")
+}
+`,
+				ExpectError: regexp.MustCompile("partitions must be between 1 and 1000000"),
+			},
+		},
+	})
+}
+
+func TestPartitionForKey(t *testing.T) {
+	testCases := []struct {
+		key         string
+		partitions  int
+		partitioner string
+		partition   int
+	}{
+		// Java client vectors from kafka-python test_partitioner.py
+		{"", 1000, "murmur2", 681},
+		{"a", 1000, "murmur2", 524},
+		{"ab", 1000, "murmur2", 434},
+		{"abc", 1000, "murmur2", 107},
+		{"123456789", 1000, "murmur2", 566},
+		// librdkafka vectors from tests/0048-partitioner.c
+		{"23456", 17, "crc32", 0xb1b451d7 % 17},
+		{"this is another string with more length to it perhaps", 17, "crc32", 0xb0150df7 % 17},
+		{"hejsan", 17, "crc32", 0xd077037e % 17},
+		// Sarama hash partitioner vectors
+		{"blah", 2, "fnv1a", 0},
+		{"blah", 3, "fnv1a", 1},
+		{"boop", 3, "fnv1a", 2},
+		{"20", 16, "fnv1a", 1},
+	}
+
+	for _, tc := range testCases {
+		partition, err := partitionForKey(tc.key, tc.partitions, tc.partitioner)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.partition, partition, "%s partition for key %q with %d partitions", tc.partitioner, tc.key, tc.partitions)
+	}
+
+	_, err := partitionForKey("abc", 0, "murmur2")
+	assert.Error(t, err, "At least one partition should be required")
+	_, err = partitionForKey("abc", maxPartitionsForKey+1, "murmur2")
+	assert.Error(t, err, "Partitions above the maximum should fail")
+	_, err = partitionForKey("abc", 10, "md5")
+	assert.Error(t, err, "Unknown partitioners should fail")
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/topicctl/pkg/admin"
+	"github.com/segmentio/topicctl/pkg/apply/assigners"
+	"github.com/segmentio/topicctl/pkg/apply/extenders"
+	"github.com/segmentio/topicctl/pkg/apply/pickers"
+	"github.com/segmentio/topicctl/pkg/config"
+)
+
+var placementStrategies = []string{
+	string(config.PlacementStrategyAny),
+	string(config.PlacementStrategyBalancedLeaders),
+	string(config.PlacementStrategyInRack),
+	string(config.PlacementStrategyCrossRack),
+	string(config.PlacementStrategyStatic),
+	string(config.PlacementStrategyStaticInRack),
+}
+
+var pickerMethods = []string{
+	string(config.PickerMethodRandomized),
+	string(config.PickerMethodClusterUse),
+	string(config.PickerMethodLowestIndex),
+}
+
+// placementPicker returns the picker used to break ties between brokers
+func placementPicker(ctx context.Context, client *admin.BrokerAdminClient, topic string, brokers []admin.BrokerInfo, method string) (pickers.Picker, error) {
+	switch config.PickerMethod(method) {
+	case config.PickerMethodClusterUse:
+		topics, err := client.GetTopics(ctx, nil, false)
+		if err != nil {
+			return nil, err
+		}
+
+		// Don't include the topic for this applier since the picker already considers
+		// broker placement within the topic and, also, the placement might change during
+		// the apply process.
+		nonAppliedTopics := []admin.TopicInfo{}
+		for _, t := range topics {
+			if t.Name != topic {
+				nonAppliedTopics = append(nonAppliedTopics, t)
+			}
+		}
+		return pickers.NewClusterUsePicker(brokers, nonAppliedTopics), nil
+	case config.PickerMethodLowestIndex:
+		return pickers.NewLowestIndexPicker(), nil
+	case config.PickerMethodRandomized:
+		return pickers.NewRandomizedPicker(), nil
+	default:
+		return nil, fmt.Errorf("unrecognized picker method: %s", method)
+	}
+}
+
+// placementAssigner returns the assigner that places the replicas of the
+// existing partitions. The any strategy keeps the replicas as they are, so no
+// assigner is returned for it.
+func placementAssigner(strategy string, brokers []admin.BrokerInfo, rackAssignments []string, picker pickers.Picker) (assigners.Assigner, error) {
+	switch config.PlacementStrategy(strategy) {
+	case config.PlacementStrategyAny:
+		return nil, nil
+	case config.PlacementStrategyBalancedLeaders:
+		return assigners.NewBalancedLeaderAssigner(brokers, picker), nil
+	case config.PlacementStrategyInRack:
+		return assigners.NewSingleRackAssigner(brokers, picker), nil
+	case config.PlacementStrategyCrossRack:
+		return assigners.NewCrossRackAssigner(brokers, picker), nil
+	case config.PlacementStrategyStaticInRack:
+		return assigners.NewStaticSingleRackAssigner(brokers, rackAssignments, picker), nil
+	default:
+		return nil, fmt.Errorf("cannot assign replicas using strategy %s", strategy)
+	}
+}
+
+// placementExtender returns the extender that places the replicas of new
+// partitions
+func placementExtender(strategy string, brokers []admin.BrokerInfo, picker pickers.Picker) (extenders.Extender, error) {
+	switch config.PlacementStrategy(strategy) {
+	case config.PlacementStrategyInRack, config.PlacementStrategyStaticInRack:
+		return extenders.NewBalancedExtender(brokers, true, picker), nil
+	case config.PlacementStrategyAny, config.PlacementStrategyBalancedLeaders, config.PlacementStrategyCrossRack:
+		return extenders.NewBalancedExtender(brokers, false, picker), nil
+	default:
+		return nil, fmt.Errorf("cannot add partitions using strategy %s", strategy)
+	}
+}
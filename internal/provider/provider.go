@@ -2,24 +2,29 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/segmentio/kafka-go/sasl"
 	"github.com/segmentio/topicctl/pkg/admin"
 )
 
 // Ensure KafkaProvider satisfies various provider interfaces.
 var _ provider.Provider = &kafkaProvider{}
+var _ provider.ProviderWithFunctions = &kafkaProvider{}
 
 // kafkaProvider defines the provider implementation.
 type kafkaProvider struct {
@@ -32,24 +37,54 @@ type kafkaProvider struct {
 
 // kafkaProviderModel describes the provider data model.
 type kafkaProviderModel struct {
-	BootstrapServers []types.String  `tfsdk:"bootstrap_servers"`
-	SASL             SASLConfigModel `tfsdk:"sasl"`
-	TLS              TLSConfigModel  `tfsdk:"tls"`
-	Timeout          types.Int64     `tfsdk:"timeout"`
+	BootstrapServers []types.String   `tfsdk:"bootstrap_servers"`
+	SASL             *SASLConfigModel `tfsdk:"sasl"`
+	TLS              *TLSConfigModel  `tfsdk:"tls"`
+	Timeout          types.Int64      `tfsdk:"timeout"`
+
+	ProtectedTopicPatterns []types.String `tfsdk:"protected_topic_patterns"`
+}
+
+// kafkaResourceData is the data passed to the resources when they are
+// configured
+type kafkaResourceData struct {
+	Client *admin.BrokerAdminClient
+	// ProtectedTopicPatterns match the names of the topics that can't be
+	// deleted
+	ProtectedTopicPatterns []*regexp.Regexp
 }
 
 // SASLConfigModel describes a SASL Authentication configuration
 type SASLConfigModel struct {
-	Enabled   types.Bool   `tfsdk:"enabled"`
-	Mechanism types.String `tfsdk:"mechanism"`
-	Username  types.String `tfsdk:"username"`
-	Password  types.String `tfsdk:"password"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Mechanism     types.String `tfsdk:"mechanism"`
+	Username      types.String `tfsdk:"username"`
+	Password      types.String `tfsdk:"password"`
+	TokenEndpoint types.String `tfsdk:"token_endpoint"`
+	ClientID      types.String `tfsdk:"client_id"`
+	ClientSecret  types.String `tfsdk:"client_secret"`
+	Scopes        types.List   `tfsdk:"scopes"`
+	Token         types.String `tfsdk:"token"`
+
+	AWSRegion          types.String `tfsdk:"aws_region"`
+	AWSProfile         types.String `tfsdk:"aws_profile"`
+	AWSRoleARN         types.String `tfsdk:"aws_role_arn"`
+	AWSRoleSessionName types.String `tfsdk:"aws_role_session_name"`
+	AWSExternalID      types.String `tfsdk:"aws_external_id"`
+	AWSAccessKeyID     types.String `tfsdk:"aws_access_key_id"`
+	AWSSecretAccessKey types.String `tfsdk:"aws_secret_access_key"`
+	AWSSessionToken    types.String `tfsdk:"aws_session_token"`
 }
 
-// TLSConfigModel describes a SASL Authentication configuration
+// TLSConfigModel describes a TLS configuration
 type TLSConfigModel struct {
-	Enabled    types.Bool `tfsdk:"enabled"`
-	SkipVerify types.Bool `tfsdk:"skip_verify"`
+	Enabled             types.Bool   `tfsdk:"enabled"`
+	SkipVerify          types.Bool   `tfsdk:"skip_verify"`
+	CACert              types.String `tfsdk:"ca_cert"`
+	ClientCert          types.String `tfsdk:"client_cert"`
+	ClientKey           types.String `tfsdk:"client_key"`
+	ClientKeyPassphrase types.String `tfsdk:"client_key_passphrase"`
+	ServerName          types.String `tfsdk:"server_name"`
 }
 
 func (p *kafkaProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -61,7 +96,7 @@ func (p *kafkaProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"bootstrap_servers": schema.ListAttribute{
-				MarkdownDescription: "A list of Kafka brokers",
+				MarkdownDescription: "A list of Kafka brokers. Each broker is tried in turn until one answers",
 				Required:            true,
 				ElementType:         types.StringType,
 			},
@@ -70,13 +105,35 @@ func (p *kafkaProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:            true,
 				Attributes: map[string]schema.Attribute{
 					"enabled": schema.BoolAttribute{
-						MarkdownDescription: "Enable TLS communication with Kafka brokers (default: true)",
+						MarkdownDescription: "Enable TLS communication with Kafka brokers. When unset, TLS is enabled only if `ca_cert` or `client_cert` is set",
 						Optional:            true,
 					},
 					"skip_verify": schema.BoolAttribute{
 						MarkdownDescription: "Skips TLS verification when connecting to the brokers (default: false)",
 						Optional:            true,
 					},
+					"ca_cert": schema.StringAttribute{
+						MarkdownDescription: "CA certificates used to verify the brokers, as PEM content or a file path. Can also be set with the `KAFKA_TLS_CA_CERT` environment variable",
+						Optional:            true,
+					},
+					"client_cert": schema.StringAttribute{
+						MarkdownDescription: "Client certificate for mutual TLS, as PEM content or a file path. Can also be set with the `KAFKA_TLS_CLIENT_CERT` environment variable",
+						Optional:            true,
+					},
+					"client_key": schema.StringAttribute{
+						MarkdownDescription: "Client private key for mutual TLS, as PEM content or a file path. Can also be set with the `KAFKA_TLS_CLIENT_KEY` environment variable",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"client_key_passphrase": schema.StringAttribute{
+						MarkdownDescription: "Passphrase of an encrypted `client_key`. Can also be set with the `KAFKA_TLS_CLIENT_KEY_PASSPHRASE` environment variable",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"server_name": schema.StringAttribute{
+						MarkdownDescription: "Server name used to verify the brokers certificates, when it does not match the broker addresses. Can also be set with the `KAFKA_TLS_SERVER_NAME` environment variable",
+						Optional:            true,
+					},
 				},
 			},
 			"sasl": schema.SingleNestedAttribute{
@@ -84,27 +141,89 @@ func (p *kafkaProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:            true,
 				Attributes: map[string]schema.Attribute{
 					"enabled": schema.BoolAttribute{
-						MarkdownDescription: "Enable SASL Authentication",
+						MarkdownDescription: "Enable SASL Authentication (default: true). Can also be set with the `KAFKA_SASL_ENABLED` environment variable",
 						Optional:            true,
 					},
 					"mechanism": schema.StringAttribute{
-						MarkdownDescription: "SASL mechanism to use. One of plain, scram-sha512, scram-sha256, aws-msk-iam (default: aws-msk-iam)",
+						MarkdownDescription: "SASL mechanism to use. One of plain, scram-sha-256, scram-sha-512, oauthbearer, aws-msk-iam (default: aws-msk-iam). Can also be set with the `KAFKA_SASL_MECHANISM` environment variable",
 						Optional:            true,
 					},
 					"username": schema.StringAttribute{
-						MarkdownDescription: "Username for SASL authentication",
+						MarkdownDescription: "Username for SASL authentication, required by the plain and scram mechanisms. Can also be set with the `KAFKA_SASL_USERNAME` environment variable",
 						Optional:            true,
 						Sensitive:           true,
 					},
 					"password": schema.StringAttribute{
-						MarkdownDescription: "Password for SASL authentication",
+						MarkdownDescription: "Password for SASL authentication, required by the plain and scram mechanisms. Can also be set with the `KAFKA_SASL_PASSWORD` environment variable",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"token_endpoint": schema.StringAttribute{
+						MarkdownDescription: "OAuth token endpoint used to fetch tokens with the client credentials grant for the oauthbearer mechanism. Can also be set with the `KAFKA_SASL_TOKEN_ENDPOINT` environment variable",
+						Optional:            true,
+					},
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "OAuth client ID for the oauthbearer mechanism. Can also be set with the `KAFKA_SASL_CLIENT_ID` environment variable",
+						Optional:            true,
+					},
+					"client_secret": schema.StringAttribute{
+						MarkdownDescription: "OAuth client secret for the oauthbearer mechanism. Can also be set with the `KAFKA_SASL_CLIENT_SECRET` environment variable",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"scopes": schema.ListAttribute{
+						MarkdownDescription: "OAuth scopes requested for the oauthbearer mechanism. Can also be set with the `KAFKA_SASL_SCOPES` environment variable",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"token": schema.StringAttribute{
+						MarkdownDescription: "Static OAuth token for the oauthbearer mechanism, used instead of fetching tokens from `token_endpoint`. Can also be set with the `KAFKA_SASL_TOKEN` environment variable",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"aws_region": schema.StringAttribute{
+						MarkdownDescription: "AWS region of the MSK cluster for the aws-msk-iam mechanism, defaults to the region of the AWS configuration. Can also be set with the `KAFKA_SASL_AWS_REGION` environment variable",
+						Optional:            true,
+					},
+					"aws_profile": schema.StringAttribute{
+						MarkdownDescription: "AWS shared configuration profile used by the aws-msk-iam mechanism. Can also be set with the `KAFKA_SASL_AWS_PROFILE` environment variable",
+						Optional:            true,
+					},
+					"aws_role_arn": schema.StringAttribute{
+						MarkdownDescription: "ARN of an IAM role to assume for the aws-msk-iam mechanism. Can also be set with the `KAFKA_SASL_AWS_ROLE_ARN` environment variable",
+						Optional:            true,
+					},
+					"aws_role_session_name": schema.StringAttribute{
+						MarkdownDescription: "Session name used when assuming `aws_role_arn` (default: terraform-provider-kafka). Can also be set with the `KAFKA_SASL_AWS_ROLE_SESSION_NAME` environment variable",
+						Optional:            true,
+					},
+					"aws_external_id": schema.StringAttribute{
+						MarkdownDescription: "External ID used when assuming `aws_role_arn`. Can also be set with the `KAFKA_SASL_AWS_EXTERNAL_ID` environment variable",
+						Optional:            true,
+					},
+					"aws_access_key_id": schema.StringAttribute{
+						MarkdownDescription: "AWS access key ID used by the aws-msk-iam mechanism instead of the AWS configuration credentials. Can also be set with the `KAFKA_SASL_AWS_ACCESS_KEY_ID` environment variable",
+						Optional:            true,
+					},
+					"aws_secret_access_key": schema.StringAttribute{
+						MarkdownDescription: "AWS secret access key used with `aws_access_key_id`. Can also be set with the `KAFKA_SASL_AWS_SECRET_ACCESS_KEY` environment variable",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"aws_session_token": schema.StringAttribute{
+						MarkdownDescription: "AWS session token used with `aws_access_key_id`. Can also be set with the `KAFKA_SASL_AWS_SESSION_TOKEN` environment variable",
 						Optional:            true,
 						Sensitive:           true,
 					},
 				},
 			},
 			"timeout": schema.Int64Attribute{
-				MarkdownDescription: "Timeout for provider operations in seconds (default: 300)",
+				MarkdownDescription: "Timeout for each request to the brokers in seconds, also used for the resources without `timeouts` (default: 300)",
+				Optional:            true,
+			},
+			"protected_topic_patterns": schema.ListAttribute{
+				MarkdownDescription: "Regular expressions matching the names of the topics that can't be deleted, regardless of their `deletion_protection`",
+				ElementType:         types.StringType,
 				Optional:            true,
 			},
 		},
@@ -121,24 +240,27 @@ func (p *kafkaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 
 	envVarPrefix := strings.ToUpper(p.typeName)
 
-	if len(config.BootstrapServers) >= 1 && config.BootstrapServers[0].IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("bootstrap_servers"),
-			"Unknown Kakfa bootstrap servers",
-			"The provider cannot create the Kafka client as there is an unknown configuration value. "+
-				fmt.Sprintf("Either target apply the source of the value first, set the value statically in the configuration, or use the %s_BOOTSTRAP_SERVERS environment variable.", envVarPrefix),
-		)
+	for _, server := range config.BootstrapServers {
+		if server.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("bootstrap_servers"),
+				"Unknown Kakfa bootstrap servers",
+				"The provider cannot create the Kafka client as there is an unknown configuration value. "+
+					fmt.Sprintf("Either target apply the source of the value first, set the value statically in the configuration, or use the %s_BOOTSTRAP_SERVERS environment variable.", envVarPrefix),
+			)
+			break
+		}
 	}
 
-	if config.SASL.Username.IsUnknown() {
+	if config.SASL != nil && config.SASL.Username.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("sasl.username"),
 			"Unknown Kafka SASL username",
 			"The provider cannot create the Kafka client as there is an unknown configuration value for the SASL username. "+
-				fmt.Sprintf("Either target apply the source of the value first, set the value statically in the configuration, or use the %s_SASL_USERNAMAE environment variable.", envVarPrefix),
+				fmt.Sprintf("Either target apply the source of the value first, set the value statically in the configuration, or use the %s_SASL_USERNAME environment variable.", envVarPrefix),
 		)
 	}
-	if config.SASL.Password.IsUnknown() {
+	if config.SASL != nil && config.SASL.Password.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("sasl.password"),
 			"Unknown Kafka SASL password",
@@ -146,6 +268,47 @@ func (p *kafkaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 				fmt.Sprintf("Either target apply the source of the value first, set the value statically in the configuration, or use the %s_SASL_PASSWORD environment variable.", envVarPrefix),
 		)
 	}
+	if config.SASL != nil {
+		saslAttributes := []struct {
+			name        string
+			description string
+			envVar      string
+			value       types.String
+		}{
+			{"token", "SASL token", "SASL_TOKEN", config.SASL.Token},
+			{"token_endpoint", "SASL token endpoint", "SASL_TOKEN_ENDPOINT", config.SASL.TokenEndpoint},
+			{"client_id", "SASL client ID", "SASL_CLIENT_ID", config.SASL.ClientID},
+			{"client_secret", "SASL client secret", "SASL_CLIENT_SECRET", config.SASL.ClientSecret},
+			{"aws_region", "SASL AWS region", "SASL_AWS_REGION", config.SASL.AWSRegion},
+			{"aws_profile", "SASL AWS profile", "SASL_AWS_PROFILE", config.SASL.AWSProfile},
+			{"aws_role_arn", "SASL AWS role ARN", "SASL_AWS_ROLE_ARN", config.SASL.AWSRoleARN},
+			{"aws_role_session_name", "SASL AWS role session name", "SASL_AWS_ROLE_SESSION_NAME", config.SASL.AWSRoleSessionName},
+			{"aws_external_id", "SASL AWS external ID", "SASL_AWS_EXTERNAL_ID", config.SASL.AWSExternalID},
+			{"aws_access_key_id", "SASL AWS access key ID", "SASL_AWS_ACCESS_KEY_ID", config.SASL.AWSAccessKeyID},
+			{"aws_secret_access_key", "SASL AWS secret access key", "SASL_AWS_SECRET_ACCESS_KEY", config.SASL.AWSSecretAccessKey},
+			{"aws_session_token", "SASL AWS session token", "SASL_AWS_SESSION_TOKEN", config.SASL.AWSSessionToken},
+		}
+		for _, attribute := range saslAttributes {
+			if attribute.value.IsUnknown() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("sasl").AtName(attribute.name),
+					fmt.Sprintf("Unknown Kafka %s", attribute.description),
+					fmt.Sprintf("The provider cannot create the Kafka client as there is an unknown configuration value for the %s. ", attribute.description)+
+						fmt.Sprintf("Either target apply the source of the value first, set the value statically in the configuration, or use the %s_%s environment variable.", envVarPrefix, attribute.envVar),
+				)
+			}
+		}
+	}
+	for i, pattern := range config.ProtectedTopicPatterns {
+		if pattern.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("protected_topic_patterns").AtListIndex(i),
+				"Unknown Kafka protected topic pattern",
+				"The provider cannot protect the topics as there is an unknown configuration value for a protected topic pattern. "+
+					"Either target apply the source of the value first, or set the value statically in the configuration.",
+			)
+		}
+	}
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -155,32 +318,57 @@ func (p *kafkaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 
 	// Bootstrap servers
 	bootstrapServersString := p.getEnv("BOOTSTRAP_SERVERS", "localhost:9092")
-	boostrapServers := strings.Split(bootstrapServersString, ",")
-	boostrapServer := boostrapServers[0] // Select the first server on the list
 	if len(config.BootstrapServers) > 0 {
-		boostrapServer = config.BootstrapServers[0].ValueString()
+		values := []string{}
+		for _, server := range config.BootstrapServers {
+			values = append(values, server.ValueString())
+		}
+		bootstrapServersString = strings.Join(values, ",")
+	}
+	bootstrapServers := splitBootstrapServers(bootstrapServersString)
+	if len(bootstrapServers) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("bootstrap_servers"),
+			"Missing Kafka bootstrap servers",
+			"The provider cannot create the Kafka client as no bootstrap server is configured.",
+		)
+		return
 	}
-	// We only require 1 server
-	brokerConfig.BrokerAddr = boostrapServer
-	tflog.SetField(ctx, "kafka_bootstrap_server", boostrapServer)
 
 	// SASL configuration
 	saslConfigEnabled := p.getEnvBool("SASL_ENABLED", true)
-	if !config.SASL.Enabled.IsNull() {
+	if config.SASL != nil && !config.SASL.Enabled.IsNull() {
 		saslConfigEnabled = config.SASL.Enabled.ValueBool()
 	}
+	var saslMechanism sasl.Mechanism
 	if saslConfigEnabled {
-		saslConfig, err := p.generateSASLConfig(ctx, config.SASL, resp)
+		saslConfig, mechanism, err := p.generateSASLConfig(ctx, config.SASL)
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to create Kafka client", err.Error())
 			return
 		}
 		brokerConfig.SASL = saslConfig
+		saslMechanism = mechanism
 	}
 
 	// Configure TLS settings
-	brokerConfig.TLS.Enabled = config.TLS.Enabled.ValueBool()
-	brokerConfig.TLS.SkipVerify = config.TLS.SkipVerify.ValueBool()
+	tlsConfig, cleanupTLS, err := p.generateTLSConfig(config.TLS)
+	defer cleanupTLS()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create Kafka client", err.Error())
+		return
+	}
+	brokerConfig.TLS = tlsConfig
+
+	protectedTopicPatterns := []*regexp.Regexp{}
+	for i, pattern := range config.ProtectedTopicPatterns {
+		re, err := regexp.Compile(pattern.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("protected_topic_patterns").AtListIndex(i), "Invalid regular expression", err.Error())
+			return
+		}
+		protectedTopicPatterns = append(protectedTopicPatterns, re)
+	}
 
 	// Configure timeout
 	defaultTimeout := int64(p.getEnvInt("TIMEOUT", 300))
@@ -191,82 +379,102 @@ func (p *kafkaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 
 	tflog.Debug(ctx, "Creating Kafka client")
 	brokerConfig.ReadOnly = true
-	dataSourceClient, err := admin.NewBrokerAdminClient(
-		ctx,
-		brokerConfig,
-	)
+	dataSourceClient, err := newBrokerAdminClient(ctx, brokerConfig, saslMechanism, bootstrapServers, kafkaClientTimeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to create Kafka client",
 			"An unexpected error occurred when creating the Kafka client "+
 				"Kafka Error: "+err.Error())
 		return
 	}
-	dataSourceClient.GetConnector().KafkaClient.Timeout = time.Duration(kafkaClientTimeout)
 	resp.DataSourceData = dataSourceClient
 
+	// Use the server that answered for the data source client first
 	brokerConfig.ReadOnly = false
-	resourceClient, err := admin.NewBrokerAdminClient(
-		ctx,
-		brokerConfig,
-	)
+	resourceClient, err := newBrokerAdminClient(ctx, brokerConfig, saslMechanism, preferBootstrapServer(bootstrapServers, dataSourceClient.GetConnector().Config.BrokerAddr), kafkaClientTimeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to create Kafka client",
 			"An unexpected error occurred when creating the Kafka client "+
 				"Kafka Error: "+err.Error())
 		return
 	}
-	resourceClient.GetConnector().KafkaClient.Timeout = time.Duration(kafkaClientTimeout)
-	resp.ResourceData = resourceClient
+	resp.ResourceData = &kafkaResourceData{
+		Client:                 resourceClient,
+		ProtectedTopicPatterns: protectedTopicPatterns,
+	}
 	tflog.Info(ctx, "Configured Kafka client", map[string]any{"success": true})
 }
 
-// generateSASLConfig returns a SASLConfig{} or an error given a SASLModel
-func (p *kafkaProvider) generateSASLConfig(ctx context.Context, sasl SASLConfigModel, resp *provider.ConfigureResponse) (admin.SASLConfig, error) {
-
-	saslMechanism := p.getEnv("SASL_MECHANISM", "aws-msk-iam")
-	if !sasl.Mechanism.IsNull() {
-		saslMechanism = sasl.Mechanism.ValueString()
-	}
-	saslUsername := p.getEnv("SASL_USERNAME", "")
-	if !sasl.Mechanism.IsNull() {
-		saslUsername = sasl.Username.ValueString()
+// newBrokerAdminClient creates a client connected to the first bootstrap server
+// that answers. The errors for every server are returned if none answers.
+// saslMechanism, when set, is used instead of the topicctl SASL configuration.
+// The timeout applies to each request, including the SASL authentication check.
+func newBrokerAdminClient(ctx context.Context, brokerConfig admin.BrokerAdminClientConfig, saslMechanism sasl.Mechanism, bootstrapServers []string, timeout time.Duration) (*admin.BrokerAdminClient, error) {
+	errs := []error{}
+	for _, server := range bootstrapServers {
+		brokerConfig.BrokerAddr = server
+		client, err := admin.NewBrokerAdminClient(ctx, brokerConfig)
+		if err == nil {
+			client.GetConnector().KafkaClient.Timeout = timeout
+		}
+		if err == nil && saslMechanism != nil {
+			err = useSASLMechanism(ctx, client, saslMechanism)
+		}
+		if err == nil {
+			tflog.Debug(ctx, fmt.Sprintf("Connected to bootstrap server %s", server))
+			return client, nil
+		}
+		tflog.Warn(ctx, fmt.Sprintf("Unable to connect to bootstrap server %s: %s", server, err))
+		errs = append(errs, fmt.Errorf("%s: %w", server, err))
 	}
-	saslPassword := p.getEnv("SASL_PASSWORD", "")
-	if !sasl.Mechanism.IsNull() {
-		saslPassword = sasl.Password.ValueString()
+	return nil, errors.Join(errs...)
+}
+
+// splitBootstrapServers returns the addresses in a comma separated list of
+// bootstrap servers
+func splitBootstrapServers(servers string) []string {
+	addresses := []string{}
+	for _, server := range strings.Split(servers, ",") {
+		server = strings.TrimSpace(server)
+		if server != "" {
+			addresses = append(addresses, server)
+		}
 	}
+	return addresses
+}
 
-	switch admin.SASLMechanism(saslMechanism) {
-	case admin.SASLMechanismScramSHA512:
-	case admin.SASLMechanismScramSHA256:
-	case admin.SASLMechanismPlain:
-		tflog.SetField(ctx, "kafka_sasl_username", saslUsername)
-		tflog.SetField(ctx, "kafka_sasl_password", saslPassword)
-		tflog.MaskFieldValuesWithFieldKeys(ctx, "kafka_sasl_password")
-		return admin.SASLConfig{
-			Enabled:   true,
-			Mechanism: admin.SASLMechanismScramSHA256,
-			Username:  saslUsername,
-			Password:  saslPassword,
-		}, nil
-	case admin.SASLMechanismAWSMSKIAM:
-		return admin.SASLConfig{
-			Enabled:   true,
-			Mechanism: admin.SASLMechanismAWSMSKIAM,
-		}, nil
+// preferBootstrapServer moves the given server to the front of the list
+func preferBootstrapServer(servers []string, preferred string) []string {
+	result := []string{preferred}
+	for _, server := range servers {
+		if server != preferred {
+			result = append(result, server)
+		}
 	}
-	return admin.SASLConfig{}, fmt.Errorf("unable to detect SASL mechanism: %s", sasl.Mechanism.ValueString())
+	return result
 }
 
 func (p *kafkaProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewTopicResource,
+		NewACLResource,
+		NewUserScramCredentialResource,
+		NewQuotaResource,
+		NewLeaderElectionResource,
 	}
 }
 
 func (p *kafkaProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewTopicDataSource,
+		NewTopicsDataSource,
+		NewBrokersDataSource,
+		NewClusterDataSource,
+	}
+}
+
+func (p *kafkaProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewPartitionForKeyFunction,
 	}
 }
 
@@ -287,6 +495,15 @@ func (p *kafkaProvider) getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getString returns the configured value, or the environment variable when
+// the value is not set
+func (p *kafkaProvider) getString(value types.String, key string, fallback string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	return p.getEnv(key, fallback)
+}
+
 func (p *kafkaProvider) getEnvInt(key string, fallback int) int {
 	envVar := p.getEnv(key, "")
 	if envVar == "" {
@@ -50,6 +50,9 @@ func TestMain(t *testing.M) {
 			"KAFKA_TRANSACTION_STATE_LOG_MIN_ISR=1",
 			"KAFKA_TRANSACTION_STATE_LOG_REPLICATION_FACTOR=1",
 			"KAFKA_LOG_DIRS=/tmp/kraft-combined-logs",
+			"KAFKA_AUTHORIZER_CLASS_NAME=org.apache.kafka.metadata.authorizer.StandardAuthorizer",
+			"KAFKA_ALLOW_EVERYONE_IF_NO_ACL_FOUND=true",
+			"KAFKA_SUPER_USERS=User:ANONYMOUS",
 		},
 		Hostname:  "kafka",
 		NetworkID: network.Network.ID,
@@ -1,10 +1,14 @@
 package provider
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/segmentio/topicctl/pkg/admin"
+	"github.com/stretchr/testify/assert"
 )
 
 const (
@@ -34,3 +38,24 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 }
 
 func testAccPreCheck(t *testing.T) {}
+
+func TestSplitBootstrapServers(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{"broker1:9092", "broker2:9092"}, splitBootstrapServers("broker1:9092, broker2:9092,"))
+	assert.Empty(splitBootstrapServers(""))
+	assert.Equal([]string{"broker2:9092", "broker1:9092"}, preferBootstrapServer([]string{"broker1:9092", "broker2:9092"}, "broker2:9092"))
+}
+
+func TestNewBrokerAdminClientFailover(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Nothing listens on these ports, so every server should fail
+	_, err := newBrokerAdminClient(ctx, admin.BrokerAdminClientConfig{}, nil, []string{"127.0.0.1:1", "127.0.0.1:2"}, time.Second)
+	assert.Error(err)
+	assert.Contains(err.Error(), "127.0.0.1:1")
+	assert.Contains(err.Error(), "127.0.0.1:2")
+}
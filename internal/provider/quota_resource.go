@@ -0,0 +1,432 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                   = &quotaResource{}
+	_ resource.ResourceWithConfigure      = &quotaResource{}
+	_ resource.ResourceWithImportState    = &quotaResource{}
+	_ resource.ResourceWithValidateConfig = &quotaResource{}
+)
+
+const (
+	quotaEntityUser     = "user"
+	quotaEntityClientID = "client-id"
+	quotaEntityIP       = "ip"
+
+	// quotaDefaultEntityName is how Kafka displays a default entity, we use
+	// the same representation in the resource ID
+	quotaDefaultEntityName = "<default>"
+
+	// DescribeClientQuotas match types
+	quotaMatchExact   int8 = 0
+	quotaMatchDefault int8 = 1
+)
+
+// quotaEntityTypes lists the valid entity types in the order used for IDs
+var quotaEntityTypes = []string{quotaEntityUser, quotaEntityClientID, quotaEntityIP}
+
+func NewQuotaResource() resource.Resource {
+	return &quotaResource{}
+}
+
+// quotaResource defines the resource implementation.
+type quotaResource struct {
+	client *admin.BrokerAdminClient
+}
+
+// QuotaResourceModel describes the resource data model.
+type QuotaResourceModel struct {
+	ID       types.String       `tfsdk:"id"`
+	Entities []QuotaEntityModel `tfsdk:"entities"`
+	Quotas   types.Map          `tfsdk:"quotas"`
+}
+
+// QuotaEntityModel describes a quota entity
+type QuotaEntityModel struct {
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (r *quotaResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_quota"
+}
+
+func (r *quotaResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Kafka client quota resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Quota id, composed of `type=name` entity pairs separated by `|`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"entities": schema.SetNestedAttribute{
+				MarkdownDescription: "Entities the quota applies to. Either a user, a client-id, a user and a client-id, or an ip",
+				Required:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: fmt.Sprintf("Entity type. One of %s", strings.Join(quotaEntityTypes, ", ")),
+							Required:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Entity name. Omit to target the default entity",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"quotas": schema.MapAttribute{
+				MarkdownDescription: "Quota values, e.g. `producer_byte_rate`, `consumer_byte_rate` or `request_percentage`",
+				ElementType:         types.Float64Type,
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *quotaResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*kafkaResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *kafkaResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *quotaResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data QuotaResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, entity := range data.Entities {
+		if entity.Type.IsUnknown() || entity.Name.IsUnknown() {
+			return
+		}
+	}
+	if err := validateQuotaEntities(data.Entities); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("entities"), "Invalid quota entities", err.Error())
+	}
+}
+
+func (r *quotaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *QuotaResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	quotas := map[string]float64{}
+	resp.Diagnostics.Append(data.Quotas.ElementsAs(ctx, &quotas, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating quota %s", quotaID(data.Entities)))
+	err := r.alterQuotas(ctx, data.Entities, quotaOps(map[string]float64{}, quotas))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create quota, got error: %s", err))
+		return
+	}
+	data.ID = types.StringValue(quotaID(data.Entities))
+	tflog.Trace(ctx, "Created quota")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *quotaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *QuotaResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	components := []kafka.DescribeClientQuotasRequestComponent{}
+	for _, entity := range data.Entities {
+		component := kafka.DescribeClientQuotasRequestComponent{
+			EntityType: entity.Type.ValueString(),
+			MatchType:  quotaMatchExact,
+			Match:      entity.Name.ValueString(),
+		}
+		if entity.Name.IsNull() {
+			component.MatchType = quotaMatchDefault
+			component.Match = ""
+		}
+		components = append(components, component)
+	}
+
+	clientResp, err := r.client.GetConnector().KafkaClient.DescribeClientQuotas(ctx, &kafka.DescribeClientQuotasRequest{
+		Components: components,
+		Strict:     true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read quota, got error: %s", err))
+		return
+	}
+	if clientResp.Error != nil {
+		resp.Diagnostics.AddError("Client Response Error", fmt.Sprintf("Unable to read quota, got error: %s", clientResp.Error))
+		return
+	}
+
+	quotas := map[string]float64{}
+	for _, entry := range clientResp.Entries {
+		for _, value := range entry.Values {
+			quotas[value.Key] = value.Value
+		}
+	}
+	if len(quotas) == 0 {
+		// If the quota does not exist, we remove it and return
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	quotasValue, diags := types.MapValueFrom(ctx, types.Float64Type, quotas)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Quotas = quotasValue
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *quotaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var data *QuotaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// Read Terraform state data into the model
+	var state *QuotaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planQuotas := map[string]float64{}
+	resp.Diagnostics.Append(data.Quotas.ElementsAs(ctx, &planQuotas, false)...)
+	stateQuotas := map[string]float64{}
+	resp.Diagnostics.Append(state.Quotas.ElementsAs(ctx, &stateQuotas, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ops := quotaOps(stateQuotas, planQuotas)
+	if len(ops) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("Updating quota %s", data.ID.ValueString()))
+		err := r.alterQuotas(ctx, data.Entities, ops)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update quota, got error: %s", err))
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *quotaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *QuotaResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	quotas := map[string]float64{}
+	resp.Diagnostics.Append(data.Quotas.ElementsAs(ctx, &quotas, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.alterQuotas(ctx, data.Entities, quotaOps(quotas, map[string]float64{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete quota, got error: %s", err))
+		return
+	}
+}
+
+func (r *quotaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	entities, err := parseQuotaID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: type=name[|type=name], using %s for default entities. Got: %q, error: %s", quotaDefaultEntityName, req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), quotaID(entities))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entities"), entities)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("quotas"), types.MapNull(types.Float64Type))...)
+}
+
+func (r *quotaResource) alterQuotas(ctx context.Context, entities []QuotaEntityModel, ops []kafka.AlterClientQuotaOps) error {
+	apiEntities := []kafka.AlterClientQuotaEntity{}
+	for _, entity := range entities {
+		apiEntities = append(apiEntities, kafka.AlterClientQuotaEntity{
+			EntityType: entity.Type.ValueString(),
+			// An empty name is sent as null, which targets the default entity
+			EntityName: entity.Name.ValueString(),
+		})
+	}
+
+	clientResp, err := r.client.GetConnector().KafkaClient.AlterClientQuotas(ctx, &kafka.AlterClientQuotasRequest{
+		Entries: []kafka.AlterClientQuotaEntry{
+			{
+				Entities: apiEntities,
+				Ops:      ops,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, entry := range clientResp.Entries {
+		if entry.Error != nil {
+			return entry.Error
+		}
+	}
+	return nil
+}
+
+// quotaOps returns the operations required to go from the current quotas to
+// the desired ones, removing any key that is no longer desired
+func quotaOps(current map[string]float64, desired map[string]float64) []kafka.AlterClientQuotaOps {
+	ops := []kafka.AlterClientQuotaOps{}
+	for k, v := range desired {
+		if currentValue, ok := current[k]; ok && currentValue == v {
+			continue
+		}
+		ops = append(ops, kafka.AlterClientQuotaOps{Key: k, Value: v})
+	}
+	for k := range current {
+		if _, ok := desired[k]; !ok {
+			ops = append(ops, kafka.AlterClientQuotaOps{Key: k, Remove: true})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Key < ops[j].Key })
+	return ops
+}
+
+// validateQuotaEntities checks the entity combination is one Kafka accepts
+func validateQuotaEntities(entities []QuotaEntityModel) error {
+	if len(entities) == 0 {
+		return fmt.Errorf("at least one entity is required")
+	}
+	seen := map[string]bool{}
+	for _, entity := range entities {
+		entityType := entity.Type.ValueString()
+		if !containsString(entityType, quotaEntityTypes) {
+			return fmt.Errorf("%q is not a valid entity type, must be one of: %s", entityType, strings.Join(quotaEntityTypes, ", "))
+		}
+		if seen[entityType] {
+			return fmt.Errorf("entity type %q can only be set once", entityType)
+		}
+		if !entity.Name.IsNull() && entity.Name.ValueString() == "" {
+			return fmt.Errorf("entity name can't be empty, omit it to target the default %s", entityType)
+		}
+		seen[entityType] = true
+	}
+	if seen[quotaEntityIP] && len(entities) > 1 {
+		return fmt.Errorf("ip entities can't be combined with other entity types")
+	}
+	return nil
+}
+
+// quotaID returns the composite ID for a set of quota entities
+func quotaID(entities []QuotaEntityModel) string {
+	parts := []string{}
+	for _, entityType := range quotaEntityTypes {
+		for _, entity := range entities {
+			if entity.Type.ValueString() != entityType {
+				continue
+			}
+			name := quotaDefaultEntityName
+			if !entity.Name.IsNull() {
+				name = entity.Name.ValueString()
+			}
+			parts = append(parts, entityType+"="+name)
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+// parseQuotaID parses a composite quota ID into its entities
+func parseQuotaID(id string) ([]QuotaEntityModel, error) {
+	entities := []QuotaEntityModel{}
+	for _, part := range strings.Split(id, "|") {
+		entityType, name, ok := strings.Cut(part, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("expected type=name, got: %q", part)
+		}
+		entity := QuotaEntityModel{
+			Type: types.StringValue(entityType),
+			Name: types.StringValue(name),
+		}
+		if name == quotaDefaultEntityName {
+			entity.Name = types.StringNull()
+		}
+		entities = append(entities, entity)
+	}
+	if err := validateQuotaEntities(entities); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func containsString(s string, values []string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
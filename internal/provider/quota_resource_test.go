@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccQuotaResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccQuotaResourceConfig(`
+    producer_byte_rate = 1048576
+    consumer_byte_rate = 2097152
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_quota.test", "id", "user=alice|client-id=<default>"),
+					resource.TestCheckResourceAttr("kafka_quota.test", "quotas.producer_byte_rate", "1048576"),
+					resource.TestCheckResourceAttr("kafka_quota.test", "quotas.consumer_byte_rate", "2097152"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "kafka_quota.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccQuotaResourceConfig(`
+    producer_byte_rate = 4194304
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_quota.test", "quotas.producer_byte_rate", "4194304"),
+					resource.TestCheckNoResourceAttr("kafka_quota.test", "quotas.consumer_byte_rate"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccQuotaResourceConfig(quotas string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_quota" "test" {
+  entities = [
+    { type = "user", name = "alice" },
+    { type = "client-id" },
+  ]
+  quotas = {
+%s
+  }
+}
+`, quotas)
+}
+
+func TestQuotaOps(t *testing.T) {
+	assert := assert.New(t)
+
+	current := map[string]float64{
+		"producer_byte_rate": 1024,
+		"consumer_byte_rate": 2048,
+		"request_percentage": 50,
+	}
+	desired := map[string]float64{
+		"producer_byte_rate":       1024,
+		"consumer_byte_rate":       4096,
+		"connection_creation_rate": 10,
+	}
+
+	expectedOps := []kafka.AlterClientQuotaOps{
+		{Key: "connection_creation_rate", Value: 10},
+		{Key: "consumer_byte_rate", Value: 4096},
+		{Key: "request_percentage", Remove: true},
+	}
+	assert.Equal(expectedOps, quotaOps(current, desired), "Only changed keys should be set, and missing keys removed")
+	assert.Empty(quotaOps(current, current), "Equal quotas should not generate any operation")
+}
+
+func TestParseQuotaID(t *testing.T) {
+	assert := assert.New(t)
+
+	entities, err := parseQuotaID("client-id=app|user=<default>")
+	assert.NoError(err)
+	assert.Equal([]QuotaEntityModel{
+		{Type: types.StringValue("client-id"), Name: types.StringValue("app")},
+		{Type: types.StringValue("user"), Name: types.StringNull()},
+	}, entities)
+	assert.Equal("user=<default>|client-id=app", quotaID(entities), "IDs should use a stable entity order")
+
+	_, err = parseQuotaID("ip=10.0.0.1|user=alice")
+	assert.Error(err, "ip entities can't be combined")
+
+	_, err = parseQuotaID("user=alice|user=bob")
+	assert.Error(err, "Duplicated entity types should fail")
+
+	_, err = parseQuotaID("group=alice")
+	assert.Error(err, "Unknown entity types should fail")
+
+	_, err = parseQuotaID("user")
+	assert.Error(err, "Entities without a name should fail")
+}
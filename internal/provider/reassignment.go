@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol/alterpartitionreassignments"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// reassignmentPollInterval is how often we check the progress of a partition
+// reassignment
+var reassignmentPollInterval = 5 * time.Second
+
+// errReassignmentTimeout is returned when a reassignment doesn't complete
+// before the timeout expires
+var errReassignmentTimeout = errors.New("timed out waiting for partition reassignment to complete")
+
+// waitForReassignment polls ListPartitionReassignments until no reassignment
+// is in progress for the given topic partitions, or the context deadline
+// expires
+func waitForReassignment(ctx context.Context, client *admin.BrokerAdminClient, topic string, partitions []int) error {
+	deadline, hasDeadline := ctx.Deadline()
+	for {
+		clientResp, err := client.GetConnector().KafkaClient.ListPartitionReassignments(ctx, &kafka.ListPartitionReassignmentsRequest{
+			Topics: map[string]kafka.ListPartitionReassignmentsRequestTopic{
+				topic: {PartitionIndexes: partitions},
+			},
+		})
+		// The deadline can expire while the request is on the wire, where it
+		// fails with an i/o timeout rather than the context error
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return errReassignmentTimeout
+		}
+		if err != nil {
+			return err
+		}
+		if clientResp.Error != nil {
+			return clientResp.Error
+		}
+
+		pending := clientResp.Topics[topic].Partitions
+		if len(pending) == 0 {
+			tflog.Info(ctx, fmt.Sprintf("Partition reassignment for topic %s completed", topic))
+			return nil
+		}
+		for _, p := range pending {
+			tflog.Info(ctx, fmt.Sprintf("Partition %d reassignment in progress", p.PartitionIndex), map[string]any{
+				"topic":             topic,
+				"replicas":          p.Replicas,
+				"adding_replicas":   p.AddingReplicas,
+				"removing_replicas": p.RemovingReplicas,
+			})
+		}
+
+		if hasDeadline && time.Now().Add(reassignmentPollInterval).After(deadline) {
+			return errReassignmentTimeout
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return errReassignmentTimeout
+			}
+			return ctx.Err()
+		case <-time.After(reassignmentPollInterval):
+		}
+	}
+}
+
+// cancelReassignment cancels any in progress reassignment for the given topic
+// partitions, reverting them to their original replicas.
+// kafka-go always sends the replicas as an array, while a cancellation requires
+// them to be null, so we send the request through the transport directly.
+func cancelReassignment(ctx context.Context, client *admin.BrokerAdminClient, topic string, partitions []int) error {
+	apiPartitions := []alterpartitionreassignments.RequestPartition{}
+	for _, partition := range partitions {
+		apiPartitions = append(apiPartitions, alterpartitionreassignments.RequestPartition{
+			PartitionIndex: int32(partition),
+			Replicas:       nil,
+		})
+	}
+
+	kafkaClient := client.GetConnector().KafkaClient
+	transport := kafkaClient.Transport
+	if transport == nil {
+		transport = kafka.DefaultTransport
+	}
+	protoResp, err := transport.RoundTrip(ctx, kafkaClient.Addr, &alterpartitionreassignments.Request{
+		TimeoutMs: int32(kafkaClient.Timeout.Milliseconds()),
+		Topics: []alterpartitionreassignments.RequestTopic{
+			{
+				Name:       topic,
+				Partitions: apiPartitions,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	apiResp := protoResp.(*alterpartitionreassignments.Response)
+	if apiResp.ErrorCode != 0 {
+		return fmt.Errorf("%w: %s", kafka.Error(apiResp.ErrorCode), apiResp.ErrorMessage)
+	}
+	partErrors := []error{}
+	for _, result := range apiResp.Results {
+		for _, p := range result.Partitions {
+			// NoReassignmentInProgress means it already completed, which is fine
+			if p.ErrorCode != 0 && kafka.Error(p.ErrorCode) != kafka.NoReassignmentInProgress {
+				partErrors = append(partErrors, fmt.Errorf("partition %d: %w", p.PartitionIndex, kafka.Error(p.ErrorCode)))
+			}
+		}
+	}
+	if len(partErrors) > 0 {
+		return fmt.Errorf("errors cancelling reassignment: %s", partErrors)
+	}
+	return nil
+}
+
+// throttleRateConfigs are the broker configs of the replication throttle rate
+var throttleRateConfigs = []string{admin.LeaderThrottledKey, admin.FollowerThrottledKey}
+
+// throttledBroker is a broker throttled by applyThrottles, with the throttle
+// rates set on it before, which are restored by removeThrottles
+type throttledBroker struct {
+	ID int
+	// PreviousRates are the throttle rate configs set on the broker before,
+	// missing configs were unset
+	PreviousRates map[string]string
+}
+
+func (b throttledBroker) String() string {
+	return strconv.Itoa(b.ID)
+}
+
+// applyThrottles sets the replica throttles on the topic, and the throttle
+// rate on every broker involved in the reassignment. It returns whether the
+// topic was throttled and the throttled brokers, so they can be removed
+// afterwards.
+func applyThrottles(
+	ctx context.Context,
+	client *admin.BrokerAdminClient,
+	topic string,
+	currAssignments []admin.PartitionAssignment,
+	desiredAssignments []admin.PartitionAssignment,
+	throttleBytes int64,
+) (bool, []throttledBroker, error) {
+	leaderThrottles := admin.LeaderPartitionThrottles(currAssignments, desiredAssignments)
+	followerThrottles := admin.FollowerPartitionThrottles(currAssignments, desiredAssignments)
+	brokerThrottles := admin.BrokerThrottles(leaderThrottles, followerThrottles, throttleBytes)
+	topicConfigEntries := admin.PartitionThrottleConfigEntries(leaderThrottles, followerThrottles)
+
+	throttledTopic := false
+	throttledBrokers := []throttledBroker{}
+
+	// The rates may be set by hand or by another reassignment, so we keep them
+	// to restore them afterwards
+	previousRates := map[int]map[string]string{}
+	if len(brokerThrottles) > 0 {
+		brokerIDs := []int{}
+		for _, brokerThrottle := range brokerThrottles {
+			brokerIDs = append(brokerIDs, brokerThrottle.Broker)
+		}
+		brokers, err := client.GetBrokers(ctx, brokerIDs)
+		if err != nil {
+			return throttledTopic, throttledBrokers, err
+		}
+		for _, broker := range brokers {
+			previousRates[broker.ID] = map[string]string{}
+			for _, key := range throttleRateConfigs {
+				if value, ok := broker.Config[key]; ok {
+					previousRates[broker.ID][key] = value
+				}
+			}
+		}
+	}
+
+	if len(topicConfigEntries) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("Applying topic throttles: %v", topicConfigEntries))
+		if _, err := client.UpdateTopicConfig(ctx, topic, topicConfigEntries, true); err != nil {
+			return throttledTopic, throttledBrokers, err
+		}
+		throttledTopic = true
+	}
+
+	for _, brokerThrottle := range brokerThrottles {
+		tflog.Debug(ctx, fmt.Sprintf("Applying throttle to broker %d", brokerThrottle.Broker))
+		if _, err := client.UpdateBrokerConfig(ctx, brokerThrottle.Broker, brokerThrottle.ConfigEntries(), false); err != nil {
+			return throttledTopic, throttledBrokers, err
+		}
+		throttledBrokers = append(throttledBrokers, throttledBroker{
+			ID:            brokerThrottle.Broker,
+			PreviousRates: previousRates[brokerThrottle.Broker],
+		})
+	}
+	tflog.Info(ctx, fmt.Sprintf("Applied throttles to brokers %v", throttledBrokers))
+
+	return throttledTopic, throttledBrokers, nil
+}
+
+// removeThrottles removes the throttles set by applyThrottles, restoring the
+// broker throttle rates set before
+func removeThrottles(
+	ctx context.Context,
+	client *admin.BrokerAdminClient,
+	topic string,
+	throttledTopic bool,
+	throttledBrokers []throttledBroker,
+) error {
+	errs := []error{}
+
+	if throttledTopic {
+		tflog.Info(ctx, "Removing topic throttles")
+		// An empty value deletes the config key
+		_, err := client.UpdateTopicConfig(ctx, topic, []kafka.ConfigEntry{
+			{ConfigName: admin.LeaderReplicasThrottledKey},
+			{ConfigName: admin.FollowerReplicasThrottledKey},
+		}, true)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("removing topic throttle: %w", err))
+		}
+	}
+
+	for _, broker := range throttledBrokers {
+		tflog.Debug(ctx, fmt.Sprintf("Removing throttle from broker %d", broker.ID))
+		// An empty value deletes the config key, the previous rates are
+		// restored otherwise
+		configEntries := []kafka.ConfigEntry{}
+		for _, key := range throttleRateConfigs {
+			configEntries = append(configEntries, kafka.ConfigEntry{ConfigName: key, ConfigValue: broker.PreviousRates[key]})
+		}
+		_, err := client.UpdateBrokerConfig(ctx, broker.ID, configEntries, true)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("removing throttle from broker %d: %w", broker.ID, err))
+		}
+	}
+	tflog.Info(ctx, fmt.Sprintf("Removed throttles from brokers %v", throttledBrokers))
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,418 @@
+package provider
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/alterpartitionreassignments"
+	"github.com/segmentio/kafka-go/protocol/describeconfigs"
+	"github.com/segmentio/kafka-go/protocol/incrementalalterconfigs"
+	"github.com/segmentio/kafka-go/protocol/listpartitionreassignments"
+	"github.com/segmentio/topicctl/pkg/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+// alterConfigsHandler answers the IncrementalAlterConfigs requests, failing
+// the ones for the failing resource name
+func alterConfigsHandler(failing string) func(protocol.Message) (protocol.Message, error) {
+	return func(req protocol.Message) (protocol.Message, error) {
+		resp := &incrementalalterconfigs.Response{}
+		for _, resource := range req.(*incrementalalterconfigs.Request).Resources {
+			result := incrementalalterconfigs.ResponseAlterResponse{
+				ResourceType: resource.ResourceType,
+				ResourceName: resource.ResourceName,
+			}
+			if resource.ResourceName == failing {
+				result.ErrorCode = int16(kafka.PolicyViolation)
+			}
+			resp.Responses = append(resp.Responses, result)
+		}
+		return resp, nil
+	}
+}
+
+// alteredConfigs returns the resources of the IncrementalAlterConfigs requests
+func alteredConfigs(requests []protocol.Message) []incrementalalterconfigs.RequestResource {
+	resources := []incrementalalterconfigs.RequestResource{}
+	for _, req := range requests {
+		resources = append(resources, req.(*incrementalalterconfigs.Request).Resources...)
+	}
+	return resources
+}
+
+func TestApplyThrottles(t *testing.T) {
+	topicResource := func(leader string, follower string) incrementalalterconfigs.RequestResource {
+		return incrementalalterconfigs.RequestResource{
+			ResourceType: int8(kafka.ResourceTypeTopic),
+			ResourceName: "test",
+			Configs: []incrementalalterconfigs.RequestConfig{
+				{Name: admin.LeaderReplicasThrottledKey, Value: leader, ConfigOperation: int8(kafka.ConfigOperationSet)},
+				{Name: admin.FollowerReplicasThrottledKey, Value: follower, ConfigOperation: int8(kafka.ConfigOperationSet)},
+			},
+		}
+	}
+	brokerResource := func(broker string) incrementalalterconfigs.RequestResource {
+		return incrementalalterconfigs.RequestResource{
+			ResourceType: int8(kafka.ResourceTypeBroker),
+			ResourceName: broker,
+			Configs: []incrementalalterconfigs.RequestConfig{
+				{Name: admin.LeaderThrottledKey, Value: "1000", ConfigOperation: int8(kafka.ConfigOperationSet)},
+				{Name: admin.FollowerThrottledKey, Value: "1000", ConfigOperation: int8(kafka.ConfigOperationSet)},
+			},
+		}
+	}
+
+	unthrottled := func(ids ...int) []throttledBroker {
+		brokers := []throttledBroker{}
+		for _, id := range ids {
+			brokers = append(brokers, throttledBroker{ID: id, PreviousRates: map[string]string{}})
+		}
+		return brokers
+	}
+
+	testCases := []struct {
+		name             string
+		current          [][]int
+		desired          [][]int
+		brokerConfigs    map[string][]describeconfigs.ResponseConfigEntry
+		failing          string
+		throttledTopic   bool
+		throttledBrokers []throttledBroker
+		requests         []incrementalalterconfigs.RequestResource
+		err              bool
+	}{
+		{
+			name:             "moved replica",
+			current:          [][]int{{1, 2}},
+			desired:          [][]int{{2, 3}},
+			throttledTopic:   true,
+			throttledBrokers: unthrottled(1, 2, 3),
+			requests: []incrementalalterconfigs.RequestResource{
+				topicResource("0:1,0:2", "0:3"),
+				brokerResource("1"),
+				brokerResource("2"),
+				brokerResource("3"),
+			},
+		},
+		{
+			name:    "rates set before",
+			current: [][]int{{1, 2}},
+			desired: [][]int{{2, 3}},
+			brokerConfigs: map[string][]describeconfigs.ResponseConfigEntry{
+				// DYNAMIC_BROKER_CONFIG
+				"2": {{ConfigName: admin.LeaderThrottledKey, ConfigValue: "5000", ConfigSource: 2}},
+			},
+			throttledTopic: true,
+			throttledBrokers: []throttledBroker{
+				{ID: 1, PreviousRates: map[string]string{}},
+				{ID: 2, PreviousRates: map[string]string{admin.LeaderThrottledKey: "5000"}},
+				{ID: 3, PreviousRates: map[string]string{}},
+			},
+			requests: []incrementalalterconfigs.RequestResource{
+				topicResource("0:1,0:2", "0:3"),
+				brokerResource("1"),
+				brokerResource("2"),
+				brokerResource("3"),
+			},
+		},
+		{
+			name:             "only moved partitions",
+			current:          [][]int{{1, 2}, {2, 3}},
+			desired:          [][]int{{1, 2}, {3, 1}},
+			throttledTopic:   true,
+			throttledBrokers: unthrottled(1, 2, 3),
+			requests: []incrementalalterconfigs.RequestResource{
+				topicResource("1:2,1:3", "1:1"),
+				brokerResource("1"),
+				brokerResource("2"),
+				brokerResource("3"),
+			},
+		},
+		{
+			name:             "unchanged assignment",
+			current:          [][]int{{1, 2}},
+			desired:          [][]int{{1, 2}},
+			throttledTopic:   false,
+			throttledBrokers: unthrottled(),
+			requests:         []incrementalalterconfigs.RequestResource{},
+		},
+		{
+			name:             "failed broker throttle",
+			current:          [][]int{{1, 2}},
+			desired:          [][]int{{2, 3}},
+			failing:          "2",
+			throttledTopic:   true,
+			throttledBrokers: unthrottled(1),
+			requests: []incrementalalterconfigs.RequestResource{
+				topicResource("0:1,0:2", "0:3"),
+				brokerResource("1"),
+				brokerResource("2"),
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			broker := newFakeBroker(t, nil, alterConfigsHandler(tc.failing))
+			broker.setConfigs(tc.brokerConfigs)
+			throttledTopic, throttledBrokers, err := applyThrottles(
+				context.Background(),
+				broker.client(t),
+				"test",
+				admin.ReplicasToAssignments(tc.current),
+				admin.ReplicasToAssignments(tc.desired),
+				1000,
+			)
+			if tc.err {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+			assert.Equal(tc.throttledTopic, throttledTopic)
+			assert.Equal(tc.throttledBrokers, throttledBrokers)
+			assert.Equal(tc.requests, alteredConfigs(broker.received()))
+		})
+	}
+}
+
+func TestRemoveThrottles(t *testing.T) {
+	topicResource := incrementalalterconfigs.RequestResource{
+		ResourceType: int8(kafka.ResourceTypeTopic),
+		ResourceName: "test",
+		Configs: []incrementalalterconfigs.RequestConfig{
+			{Name: admin.LeaderReplicasThrottledKey, ConfigOperation: int8(kafka.ConfigOperationDelete)},
+			{Name: admin.FollowerReplicasThrottledKey, ConfigOperation: int8(kafka.ConfigOperationDelete)},
+		},
+	}
+	brokerResource := func(broker string) incrementalalterconfigs.RequestResource {
+		return incrementalalterconfigs.RequestResource{
+			ResourceType: int8(kafka.ResourceTypeBroker),
+			ResourceName: broker,
+			Configs: []incrementalalterconfigs.RequestConfig{
+				{Name: admin.LeaderThrottledKey, ConfigOperation: int8(kafka.ConfigOperationDelete)},
+				{Name: admin.FollowerThrottledKey, ConfigOperation: int8(kafka.ConfigOperationDelete)},
+			},
+		}
+	}
+	throttled := func(ids ...int) []throttledBroker {
+		brokers := []throttledBroker{}
+		for _, id := range ids {
+			brokers = append(brokers, throttledBroker{ID: id, PreviousRates: map[string]string{}})
+		}
+		return brokers
+	}
+
+	testCases := []struct {
+		name             string
+		throttledTopic   bool
+		throttledBrokers []throttledBroker
+		failing          string
+		requests         []incrementalalterconfigs.RequestResource
+		err              string
+	}{
+		{
+			name:             "throttled topic and brokers",
+			throttledTopic:   true,
+			throttledBrokers: throttled(1, 2, 3),
+			requests: []incrementalalterconfigs.RequestResource{
+				topicResource,
+				brokerResource("1"),
+				brokerResource("2"),
+				brokerResource("3"),
+			},
+		},
+		{
+			name:             "nothing throttled",
+			throttledTopic:   false,
+			throttledBrokers: throttled(),
+			requests:         []incrementalalterconfigs.RequestResource{},
+		},
+		{
+			name:           "rates set before",
+			throttledTopic: false,
+			throttledBrokers: []throttledBroker{
+				{ID: 2, PreviousRates: map[string]string{admin.LeaderThrottledKey: "5000"}},
+			},
+			// Only the rates missing before are removed
+			requests: []incrementalalterconfigs.RequestResource{
+				{
+					ResourceType: int8(kafka.ResourceTypeBroker),
+					ResourceName: "2",
+					Configs: []incrementalalterconfigs.RequestConfig{
+						{Name: admin.LeaderThrottledKey, Value: "5000", ConfigOperation: int8(kafka.ConfigOperationSet)},
+						{Name: admin.FollowerThrottledKey, ConfigOperation: int8(kafka.ConfigOperationDelete)},
+					},
+				},
+			},
+		},
+		{
+			name:             "failed broker cleanup",
+			throttledTopic:   true,
+			throttledBrokers: throttled(1, 2, 3),
+			failing:          "2",
+			// The remaining throttles are still removed
+			requests: []incrementalalterconfigs.RequestResource{
+				topicResource,
+				brokerResource("1"),
+				brokerResource("2"),
+				brokerResource("3"),
+			},
+			err: "removing throttle from broker 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			broker := newFakeBroker(t, nil, alterConfigsHandler(tc.failing))
+			err := removeThrottles(context.Background(), broker.client(t), "test", tc.throttledTopic, tc.throttledBrokers)
+			if tc.err != "" {
+				assert.ErrorContains(err, tc.err)
+			} else {
+				assert.NoError(err)
+			}
+			assert.Equal(tc.requests, alteredConfigs(broker.received()))
+		})
+	}
+}
+
+// reassignmentHandler answers the reassignment requests. The reassignment of
+// partition 0 is listed as in progress for the first pendingPolls lists, or
+// forever when it is negative, and its cancellation fails with cancelError.
+func reassignmentHandler(pendingPolls int, cancelError kafka.Error) func(protocol.Message) (protocol.Message, error) {
+	var polls atomic.Int32
+	return func(req protocol.Message) (protocol.Message, error) {
+		switch r := req.(type) {
+		case *listpartitionreassignments.Request:
+			resp := &listpartitionreassignments.Response{}
+			if pendingPolls < 0 || int(polls.Add(1)) <= pendingPolls {
+				resp.Topics = []listpartitionreassignments.ResponseTopic{
+					{Name: "test", Partitions: []listpartitionreassignments.ResponsePartition{
+						{PartitionIndex: 0, Replicas: []int32{1, 2}, AddingReplicas: []int32{2}},
+					}},
+				}
+			}
+			return resp, nil
+		case *alterpartitionreassignments.Request:
+			resp := &alterpartitionreassignments.Response{}
+			for _, topic := range r.Topics {
+				result := alterpartitionreassignments.ResponseResult{Name: topic.Name}
+				for _, partition := range topic.Partitions {
+					errorCode := int16(0)
+					if partition.Replicas == nil {
+						errorCode = int16(cancelError)
+					}
+					result.Partitions = append(result.Partitions, alterpartitionreassignments.ResponsePartition{
+						PartitionIndex: partition.PartitionIndex,
+						ErrorCode:      errorCode,
+					})
+				}
+				resp.Results = append(resp.Results, result)
+			}
+			return resp, nil
+		default:
+			return alterConfigsHandler("")(req)
+		}
+	}
+}
+
+// requestsOf returns the requests of type T
+func requestsOf[T protocol.Message](requests []protocol.Message) []T {
+	matching := []T{}
+	for _, req := range requests {
+		if r, ok := req.(T); ok {
+			matching = append(matching, r)
+		}
+	}
+	return matching
+}
+
+// fastReassignmentPolls shortens the reassignment poll interval for the test
+func fastReassignmentPolls(t *testing.T) {
+	interval := reassignmentPollInterval
+	reassignmentPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { reassignmentPollInterval = interval })
+}
+
+func TestWaitForReassignment(t *testing.T) {
+	fastReassignmentPolls(t)
+
+	testCases := []struct {
+		name         string
+		pendingPolls int
+		timeout      time.Duration
+		polls        int
+		err          error
+	}{
+		{name: "completed", pendingPolls: 0, timeout: time.Minute, polls: 1},
+		{name: "in progress", pendingPolls: 2, timeout: time.Minute, polls: 3},
+		{name: "timeout", pendingPolls: -1, timeout: 100 * time.Millisecond, err: errReassignmentTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			broker := newFakeBroker(t, nil, reassignmentHandler(tc.pendingPolls, 0))
+			client := broker.client(t)
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			err := waitForReassignment(ctx, client, "test", []int{0})
+			assert.ErrorIs(err, tc.err)
+			lists := requestsOf[*listpartitionreassignments.Request](broker.received())
+			if tc.err == nil {
+				assert.Len(lists, tc.polls)
+			}
+			for _, list := range lists {
+				assert.Equal("test", list.Topics[0].Name)
+				assert.Equal([]int32{0}, list.Topics[0].PartitionIndexes)
+			}
+		})
+	}
+}
+
+func TestCancelReassignment(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cancelError kafka.Error
+		err         string
+	}{
+		{name: "cancelled"},
+		{name: "already completed", cancelError: kafka.NoReassignmentInProgress},
+		{name: "failed", cancelError: kafka.InvalidReplicaAssignment, err: "partition 0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			broker := newFakeBroker(t, nil, reassignmentHandler(0, tc.cancelError))
+			err := cancelReassignment(context.Background(), broker.client(t), "test", []int{0, 1})
+			if tc.err != "" {
+				assert.ErrorContains(err, tc.err)
+			} else {
+				assert.NoError(err)
+			}
+
+			// The replicas must be null to cancel the reassignment
+			alters := requestsOf[*alterpartitionreassignments.Request](broker.received())
+			if assert.Len(alters, 1) {
+				assert.Equal([]alterpartitionreassignments.RequestTopic{
+					{Name: "test", Partitions: []alterpartitionreassignments.RequestPartition{
+						{PartitionIndex: 0, Replicas: nil},
+						{PartitionIndex: 1, Replicas: nil},
+					}},
+				}, alters[0].Topics)
+			}
+		})
+	}
+}
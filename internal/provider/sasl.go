@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// saslMechanismOAuthBearer is not supported by topicctl, so its sasl.Mechanism
+// is set on the clients once they are created
+const saslMechanismOAuthBearer admin.SASLMechanism = "oauthbearer"
+
+// saslMechanismAliases maps the mechanism names documented by previous
+// versions of the provider to their current name
+var saslMechanismAliases = map[string]admin.SASLMechanism{
+	"scram-sha256": admin.SASLMechanismScramSHA256,
+	"scram-sha512": admin.SASLMechanismScramSHA512,
+}
+
+// generateSASLConfig returns a SASLConfig{} or an error given a SASLModel.
+// Mechanisms and options that topicctl doesn't support return a disabled
+// SASLConfig{} and the sasl.Mechanism to set with useSASLMechanism instead.
+func (p *kafkaProvider) generateSASLConfig(ctx context.Context, saslConfig *SASLConfigModel) (admin.SASLConfig, sasl.Mechanism, error) {
+	if saslConfig == nil {
+		saslConfig = &SASLConfigModel{}
+	}
+
+	saslMechanism := p.getEnv("SASL_MECHANISM", string(admin.SASLMechanismAWSMSKIAM))
+	if !saslConfig.Mechanism.IsNull() {
+		saslMechanism = saslConfig.Mechanism.ValueString()
+	}
+	mechanism, err := parseSASLMechanism(saslMechanism)
+	if err != nil {
+		return admin.SASLConfig{}, nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Using SASL mechanism %s", mechanism))
+
+	switch mechanism {
+	case admin.SASLMechanismPlain, admin.SASLMechanismScramSHA256, admin.SASLMechanismScramSHA512:
+		config, err := p.saslCredentialsConfig(mechanism, saslConfig)
+		return config, nil, err
+	case admin.SASLMechanismAWSMSKIAM:
+		options := p.awsMSKIAMOptions(saslConfig)
+		if options.isSet() {
+			mechanism, err := awsMSKIAMMechanism(ctx, options)
+			return admin.SASLConfig{}, mechanism, err
+		}
+		return admin.SASLConfig{
+			Enabled:   true,
+			Mechanism: admin.SASLMechanismAWSMSKIAM,
+		}, nil, nil
+	case saslMechanismOAuthBearer:
+		mechanism, err := p.saslOAuthBearerMechanism(ctx, saslConfig)
+		return admin.SASLConfig{}, mechanism, err
+	}
+	return admin.SASLConfig{}, nil, fmt.Errorf("unable to detect SASL mechanism: %s", saslMechanism)
+}
+
+// saslOAuthBearerMechanism returns the OAUTHBEARER mechanism, using either a
+// static token or the client credentials grant against the token endpoint
+func (p *kafkaProvider) saslOAuthBearerMechanism(ctx context.Context, saslConfig *SASLConfigModel) (sasl.Mechanism, error) {
+	token := p.getString(saslConfig.Token, "SASL_TOKEN", "")
+	if token != "" {
+		return &oauthBearerMechanism{TokenSource: staticTokenSource(token)}, nil
+	}
+
+	tokenEndpoint := p.getString(saslConfig.TokenEndpoint, "SASL_TOKEN_ENDPOINT", "")
+	clientID := p.getString(saslConfig.ClientID, "SASL_CLIENT_ID", "")
+	clientSecret := p.getString(saslConfig.ClientSecret, "SASL_CLIENT_SECRET", "")
+	// Scopes in the environment can be separated by spaces or commas
+	scopes := strings.FieldsFunc(p.getEnv("SASL_SCOPES", ""), func(r rune) bool { return r == ' ' || r == ',' })
+	if !saslConfig.Scopes.IsNull() {
+		scopes = []string{}
+		diags := saslConfig.Scopes.ElementsAs(ctx, &scopes, false)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to read scopes: %v", diags)
+		}
+	}
+
+	missing := []string{}
+	if tokenEndpoint == "" {
+		missing = append(missing, "token_endpoint")
+	}
+	if clientID == "" {
+		missing = append(missing, "client_id")
+	}
+	if clientSecret == "" {
+		missing = append(missing, "client_secret")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the %s SASL mechanism requires a token, or %s", saslMechanismOAuthBearer, strings.Join(missing, ", "))
+	}
+
+	return &oauthBearerMechanism{
+		TokenSource: &clientCredentialsTokenSource{
+			TokenEndpoint: tokenEndpoint,
+			ClientID:      clientID,
+			ClientSecret:  clientSecret,
+			Scopes:        scopes,
+		},
+	}, nil
+}
+
+// useSASLMechanism replaces the client transport with one authenticating with
+// the given mechanism, and checks that the authentication succeeds
+func useSASLMechanism(ctx context.Context, client *admin.BrokerAdminClient, mechanism sasl.Mechanism) error {
+	connector := client.GetConnector()
+	transport, ok := connector.KafkaClient.Transport.(*kafka.Transport)
+	if !ok {
+		return errors.New("unable to set the SASL mechanism on the Kafka client transport")
+	}
+	connector.KafkaClient.Transport = &kafka.Transport{
+		Dial: transport.Dial,
+		TLS:  transport.TLS,
+		SASL: mechanism,
+	}
+	transport.CloseIdleConnections()
+
+	dialer := *connector.Dialer
+	dialer.SASLMechanism = mechanism
+	connector.Dialer = &dialer
+
+	_, err := connector.KafkaClient.Metadata(ctx, &kafka.MetadataRequest{})
+	return err
+}
+
+// saslCredentialsConfig returns the SASLConfig{} for the mechanisms that
+// authenticate with a username and password
+func (p *kafkaProvider) saslCredentialsConfig(mechanism admin.SASLMechanism, saslConfig *SASLConfigModel) (admin.SASLConfig, error) {
+	saslUsername := p.getEnv("SASL_USERNAME", "")
+	if !saslConfig.Username.IsNull() {
+		saslUsername = saslConfig.Username.ValueString()
+	}
+	saslPassword := p.getEnv("SASL_PASSWORD", "")
+	if !saslConfig.Password.IsNull() {
+		saslPassword = saslConfig.Password.ValueString()
+	}
+
+	if saslUsername == "" {
+		return admin.SASLConfig{}, fmt.Errorf("the %s SASL mechanism requires a username", mechanism)
+	}
+	if saslPassword == "" {
+		return admin.SASLConfig{}, fmt.Errorf("the %s SASL mechanism requires a password", mechanism)
+	}
+
+	return admin.SASLConfig{
+		Enabled:   true,
+		Mechanism: mechanism,
+		Username:  saslUsername,
+		Password:  saslPassword,
+	}, nil
+}
+
+// parseSASLMechanism returns the SASL mechanism for the given name
+func parseSASLMechanism(name string) (admin.SASLMechanism, error) {
+	if mechanism, ok := saslMechanismAliases[name]; ok {
+		return mechanism, nil
+	}
+	if strings.EqualFold(name, string(saslMechanismOAuthBearer)) {
+		return saslMechanismOAuthBearer, nil
+	}
+	mechanism, err := admin.SASLNameToMechanism(name)
+	if err != nil {
+		return "", fmt.Errorf("unable to detect SASL mechanism: %s", name)
+	}
+	return mechanism, nil
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/topicctl/pkg/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSASLConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		sasl     *SASLConfigModel
+		expected admin.SASLConfig
+		err      bool
+	}{
+		{
+			name: "defaults to aws-msk-iam",
+			sasl: nil,
+			expected: admin.SASLConfig{
+				Enabled:   true,
+				Mechanism: admin.SASLMechanismAWSMSKIAM,
+			},
+		},
+		{
+			name: "plain from config",
+			sasl: &SASLConfigModel{
+				Mechanism: types.StringValue("plain"),
+				Username:  types.StringValue("alice"),
+				Password:  types.StringValue("secret"),
+			},
+			expected: admin.SASLConfig{
+				Enabled:   true,
+				Mechanism: admin.SASLMechanismPlain,
+				Username:  "alice",
+				Password:  "secret",
+			},
+		},
+		{
+			name: "plain from env",
+			env: map[string]string{
+				"KAFKA_SASL_MECHANISM": "plain",
+				"KAFKA_SASL_USERNAME":  "alice",
+				"KAFKA_SASL_PASSWORD":  "secret",
+			},
+			sasl: nil,
+			expected: admin.SASLConfig{
+				Enabled:   true,
+				Mechanism: admin.SASLMechanismPlain,
+				Username:  "alice",
+				Password:  "secret",
+			},
+		},
+		{
+			name: "scram-sha-256 with credentials from env",
+			env: map[string]string{
+				"KAFKA_SASL_USERNAME": "alice",
+				"KAFKA_SASL_PASSWORD": "secret",
+			},
+			sasl: &SASLConfigModel{
+				Mechanism: types.StringValue("scram-sha-256"),
+			},
+			expected: admin.SASLConfig{
+				Enabled:   true,
+				Mechanism: admin.SASLMechanismScramSHA256,
+				Username:  "alice",
+				Password:  "secret",
+			},
+		},
+		{
+			name: "scram-sha-512 with the config taking precedence over env",
+			env: map[string]string{
+				"KAFKA_SASL_MECHANISM": "plain",
+				"KAFKA_SASL_USERNAME":  "bob",
+				"KAFKA_SASL_PASSWORD":  "secret",
+			},
+			sasl: &SASLConfigModel{
+				Mechanism: types.StringValue("SCRAM_SHA_512"),
+				Username:  types.StringValue("alice"),
+			},
+			expected: admin.SASLConfig{
+				Enabled:   true,
+				Mechanism: admin.SASLMechanismScramSHA512,
+				Username:  "alice",
+				Password:  "secret",
+			},
+		},
+		{
+			name: "legacy scram-sha512 name",
+			env: map[string]string{
+				"KAFKA_SASL_MECHANISM": "scram-sha512",
+				"KAFKA_SASL_USERNAME":  "alice",
+				"KAFKA_SASL_PASSWORD":  "secret",
+			},
+			expected: admin.SASLConfig{
+				Enabled:   true,
+				Mechanism: admin.SASLMechanismScramSHA512,
+				Username:  "alice",
+				Password:  "secret",
+			},
+		},
+		{
+			name: "legacy scram-sha256 name",
+			sasl: &SASLConfigModel{
+				Mechanism: types.StringValue("scram-sha256"),
+				Username:  types.StringValue("alice"),
+				Password:  types.StringValue("secret"),
+			},
+			expected: admin.SASLConfig{
+				Enabled:   true,
+				Mechanism: admin.SASLMechanismScramSHA256,
+				Username:  "alice",
+				Password:  "secret",
+			},
+		},
+		{
+			name: "aws-msk-iam ignores credentials",
+			env: map[string]string{
+				"KAFKA_SASL_MECHANISM": "aws-msk-iam",
+				"KAFKA_SASL_USERNAME":  "alice",
+			},
+			expected: admin.SASLConfig{
+				Enabled:   true,
+				Mechanism: admin.SASLMechanismAWSMSKIAM,
+			},
+		},
+		{
+			name: "plain without username",
+			sasl: &SASLConfigModel{
+				Mechanism: types.StringValue("plain"),
+				Password:  types.StringValue("secret"),
+			},
+			err: true,
+		},
+		{
+			name: "scram-sha-256 without password",
+			env: map[string]string{
+				"KAFKA_SASL_MECHANISM": "scram-sha-256",
+				"KAFKA_SASL_USERNAME":  "alice",
+			},
+			err: true,
+		},
+		{
+			name: "scram-sha-512 without credentials",
+			sasl: &SASLConfigModel{
+				Mechanism: types.StringValue("scram-sha-512"),
+			},
+			err: true,
+		},
+		{
+			name: "unknown mechanism",
+			env: map[string]string{
+				"KAFKA_SASL_MECHANISM": "gssapi",
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			unsetSASLEnv(t)
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			p := &kafkaProvider{typeName: "kafka"}
+			config, _, err := p.generateSASLConfig(context.Background(), tc.sasl)
+			if tc.err {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expected, config)
+		})
+	}
+}
+
+// unsetSASLEnv unsets the SASL environment variables for the duration of the
+// test
+func unsetSASLEnv(t *testing.T) {
+	keys := []string{
+		"KAFKA_SASL_MECHANISM", "KAFKA_SASL_USERNAME", "KAFKA_SASL_PASSWORD",
+		"KAFKA_SASL_TOKEN_ENDPOINT", "KAFKA_SASL_CLIENT_ID", "KAFKA_SASL_CLIENT_SECRET", "KAFKA_SASL_SCOPES", "KAFKA_SASL_TOKEN",
+		"KAFKA_SASL_AWS_REGION", "KAFKA_SASL_AWS_PROFILE", "KAFKA_SASL_AWS_ROLE_ARN", "KAFKA_SASL_AWS_ROLE_SESSION_NAME",
+		"KAFKA_SASL_AWS_EXTERNAL_ID", "KAFKA_SASL_AWS_ACCESS_KEY_ID", "KAFKA_SASL_AWS_SECRET_ACCESS_KEY", "KAFKA_SASL_AWS_SESSION_TOKEN",
+	}
+	for _, key := range keys {
+		// Setenv restores the original value once the test completes
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// generateTLSConfig returns the admin.TLSConfig for the given TLSConfigModel.
+// topicctl only loads certificates from files, so the certificates given as
+// PEM content, and decrypted keys, are written to a temporary directory. The
+// returned function removes it once the clients are created.
+func (p *kafkaProvider) generateTLSConfig(tlsConfig *TLSConfigModel) (admin.TLSConfig, func(), error) {
+	config := admin.TLSConfig{}
+	cleanup := func() {}
+	if tlsConfig == nil {
+		tlsConfig = &TLSConfigModel{}
+	}
+
+	config.Enabled = tlsConfig.Enabled.ValueBool()
+	config.SkipVerify = tlsConfig.SkipVerify.ValueBool()
+	config.ServerName = p.getString(tlsConfig.ServerName, "TLS_SERVER_NAME", "")
+
+	caCert := p.getString(tlsConfig.CACert, "TLS_CA_CERT", "")
+	clientCert := p.getString(tlsConfig.ClientCert, "TLS_CLIENT_CERT", "")
+	clientKey := p.getString(tlsConfig.ClientKey, "TLS_CLIENT_KEY", "")
+	clientKeyPassphrase := p.getString(tlsConfig.ClientKeyPassphrase, "TLS_CLIENT_KEY_PASSPHRASE", "")
+
+	if (clientCert == "") != (clientKey == "") {
+		return config, cleanup, errors.New("client_cert and client_key must be set together")
+	}
+	if caCert == "" && clientCert == "" {
+		return config, cleanup, nil
+	}
+	// The certificates are only used over TLS, so setting them enables it
+	// unless it is explicitly disabled
+	if tlsConfig.Enabled.IsNull() {
+		config.Enabled = true
+	}
+	if !config.Enabled {
+		return config, cleanup, errors.New("ca_cert, client_cert and client_key can't be used with TLS disabled, remove them or set enabled to true")
+	}
+
+	dir, err := os.MkdirTemp("", "terraform-provider-kafka-tls")
+	if err != nil {
+		return config, cleanup, err
+	}
+	cleanup = func() { os.RemoveAll(dir) }
+
+	if caCert != "" {
+		contents, err := pemOrFile(caCert)
+		if err != nil {
+			return config, cleanup, fmt.Errorf("unable to read ca_cert: %w", err)
+		}
+		config.CACertPath, err = writeTLSFile(dir, "ca.pem", contents)
+		if err != nil {
+			return config, cleanup, err
+		}
+	}
+
+	if clientCert != "" {
+		contents, err := pemOrFile(clientCert)
+		if err != nil {
+			return config, cleanup, fmt.Errorf("unable to read client_cert: %w", err)
+		}
+		config.CertPath, err = writeTLSFile(dir, "cert.pem", contents)
+		if err != nil {
+			return config, cleanup, err
+		}
+
+		contents, err = pemOrFile(clientKey)
+		if err != nil {
+			return config, cleanup, fmt.Errorf("unable to read client_key: %w", err)
+		}
+		if clientKeyPassphrase != "" {
+			contents, err = decryptPEMKey(contents, clientKeyPassphrase)
+			if err != nil {
+				return config, cleanup, fmt.Errorf("unable to decrypt client_key: %w", err)
+			}
+		}
+		config.KeyPath, err = writeTLSFile(dir, "key.pem", contents)
+		if err != nil {
+			return config, cleanup, err
+		}
+	}
+
+	return config, cleanup, nil
+}
+
+// pemOrFile returns the value when it is PEM content, or the contents of the
+// file it points to otherwise
+func pemOrFile(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+// decryptPEMKey decrypts a PEM encoded private key encrypted with a passphrase
+func decryptPEMKey(contents []byte, passphrase string) ([]byte, error) {
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	//nolint:staticcheck // Legacy PEM encryption is still what most tools generate for keys
+	if !x509.IsEncryptedPEMBlock(block) {
+		return nil, errors.New("the key is not encrypted with a supported PEM encryption")
+	}
+	//nolint:staticcheck // Legacy PEM encryption is still what most tools generate for keys
+	der, err := x509.DecryptPEMBlock(block, []byte(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: der}), nil
+}
+
+// writeTLSFile writes the contents to a file only readable by the current user
+func writeTLSFile(dir string, name string, contents []byte) (string, error) {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, contents, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecryptPEMKey(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(err)
+	der := x509.MarshalPKCS1PrivateKey(key)
+	//nolint:staticcheck // Legacy PEM encryption is what we need to support
+	block, err := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", der, []byte("secret"), x509.PEMCipherAES256)
+	assert.NoError(err)
+	encrypted := pem.EncodeToMemory(block)
+
+	decrypted, err := decryptPEMKey(encrypted, "secret")
+	assert.NoError(err)
+	assert.Equal(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der}), decrypted)
+
+	_, err = decryptPEMKey(encrypted, "wrong")
+	assert.Error(err, "A wrong passphrase should fail")
+
+	_, err = decryptPEMKey(decrypted, "secret")
+	assert.Error(err, "Keys that aren't encrypted should fail")
+}
+
+func TestPemOrFile(t *testing.T) {
+	assert := assert.New(t)
+
+	content := "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	value, err := pemOrFile(content)
+	assert.NoError(err)
+	assert.Equal([]byte(content), value)
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(os.WriteFile(path, []byte(content), 0600))
+	value, err = pemOrFile(path)
+	assert.NoError(err)
+	assert.Equal([]byte(content), value)
+
+	_, err = pemOrFile(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(err)
+}
+
+func TestGenerateTLSConfig(t *testing.T) {
+	assert := assert.New(t)
+	p := &kafkaProvider{typeName: "kafka"}
+
+	content := "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	t.Setenv("KAFKA_TLS_CA_CERT", content)
+	t.Setenv("KAFKA_TLS_SERVER_NAME", "kafka.internal")
+
+	config, cleanup, err := p.generateTLSConfig(&TLSConfigModel{
+		Enabled:    types.BoolValue(true),
+		ServerName: types.StringValue("broker.internal"),
+	})
+	assert.NoError(err)
+	assert.True(config.Enabled)
+	assert.Equal("broker.internal", config.ServerName, "The configuration should take precedence over the environment")
+	caCert, err := os.ReadFile(config.CACertPath)
+	assert.NoError(err)
+	assert.Equal(content, string(caCert), "The environment should be used when the configuration is unset")
+	cleanup()
+	assert.NoFileExists(config.CACertPath, "Temporary files should be removed")
+
+	config, cleanup, err = p.generateTLSConfig(nil)
+	assert.NoError(err)
+	assert.True(config.Enabled, "Setting a certificate should enable TLS when it is unset")
+	cleanup()
+
+	_, cleanup, err = p.generateTLSConfig(&TLSConfigModel{Enabled: types.BoolValue(false)})
+	cleanup()
+	assert.Error(err, "Certificates should fail when TLS is disabled")
+
+	t.Setenv("KAFKA_TLS_CA_CERT", "")
+	config, cleanup, err = p.generateTLSConfig(nil)
+	cleanup()
+	assert.NoError(err)
+	assert.False(config.Enabled, "TLS should be disabled by default without certificates")
+
+	_, cleanup, err = p.generateTLSConfig(&TLSConfigModel{ClientCert: types.StringValue(content)})
+	defer cleanup()
+	assert.Error(err, "A client certificate without key should fail")
+}
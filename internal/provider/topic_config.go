@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// configSourceDynamicTopicConfig is the DescribeConfigs source of the configs
+// set on the topic itself, DYNAMIC_TOPIC_CONFIG
+const configSourceDynamicTopicConfig int8 = 1
+
+// throttleConfigs are the topic configs set by reassignment_throttle while
+// partitions are reassigned. They are not tracked in configuration, so a
+// reassignment left running by a timeout isn't unthrottled by the next apply.
+var throttleConfigs = []string{admin.LeaderReplicasThrottledKey, admin.FollowerReplicasThrottledKey}
+
+// describeTopicConfig returns the configs set on the topic, and the full set
+// of configs resolved for the topic including the inherited ones
+func describeTopicConfig(ctx context.Context, client *admin.BrokerAdminClient, name string) (map[string]string, map[string]string, error) {
+	clientResp, err := client.GetConnector().KafkaClient.DescribeConfigs(ctx, &kafka.DescribeConfigsRequest{
+		Resources: []kafka.DescribeConfigRequestResource{
+			{
+				ResourceType: kafka.ResourceTypeTopic,
+				ResourceName: name,
+			},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, resource := range clientResp.Resources {
+		if resource.Error != nil {
+			return nil, nil, resource.Error
+		}
+		if resource.ResourceName != name {
+			continue
+		}
+		config, effectiveConfig := splitTopicConfig(resource.ConfigEntries)
+		return config, effectiveConfig, nil
+	}
+	return nil, nil, fmt.Errorf("no configs returned for topic %s", name)
+}
+
+// splitTopicConfig splits the config entries into the ones set on the topic,
+// which are tracked in configuration, and all the entries. The throttle
+// configs are only included in all the entries.
+func splitTopicConfig(entries []kafka.DescribeConfigResponseConfigEntry) (map[string]string, map[string]string) {
+	config := map[string]string{}
+	effectiveConfig := map[string]string{}
+	for _, entry := range entries {
+		effectiveConfig[entry.ConfigName] = entry.ConfigValue
+		if entry.ConfigSource == configSourceDynamicTopicConfig && !containsString(entry.ConfigName, throttleConfigs) {
+			config[entry.ConfigName] = entry.ConfigValue
+		}
+	}
+	return config, effectiveConfig
+}
+
+// configMapValue returns the configs as a map of strings
+func configMapValue(config map[string]string) types.Map {
+	elements := map[string]attr.Value{}
+	for k, v := range config {
+		elements[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
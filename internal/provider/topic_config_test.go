@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTopicConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config, effectiveConfig := splitTopicConfig([]kafka.DescribeConfigResponseConfigEntry{
+		{ConfigName: "cleanup.policy", ConfigValue: "compact", ConfigSource: configSourceDynamicTopicConfig},
+		// Set by a reassignment in progress
+		{ConfigName: "leader.replication.throttled.replicas", ConfigValue: "0:1", ConfigSource: configSourceDynamicTopicConfig},
+		// DYNAMIC_DEFAULT_BROKER_CONFIG
+		{ConfigName: "retention.ms", ConfigValue: "3600000", ConfigSource: 3},
+		// STATIC_BROKER_CONFIG
+		{ConfigName: "min.insync.replicas", ConfigValue: "2", ConfigSource: 4},
+		// DEFAULT_CONFIG
+		{ConfigName: "segment.bytes", ConfigValue: "1073741824", ConfigSource: 5},
+	})
+	assert.Equal(map[string]string{"cleanup.policy": "compact"}, config)
+	assert.Equal(map[string]string{
+		"cleanup.policy":                        "compact",
+		"leader.replication.throttled.replicas": "0:1",
+		"retention.ms":                          "3600000",
+		"min.insync.replicas":                   "2",
+		"segment.bytes":                         "1073741824",
+	}, effectiveConfig)
+}
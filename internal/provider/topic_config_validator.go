@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// topicConfigType is the type of the value of a topic config
+type topicConfigType int
+
+const (
+	topicConfigString topicConfigType = iota
+	topicConfigBoolean
+	topicConfigInt
+	topicConfigLong
+	topicConfigDouble
+	topicConfigList
+)
+
+// topicConfigSpec describes the values accepted by a topic config
+type topicConfigSpec struct {
+	Type topicConfigType
+	// Min and Max bound the numeric types
+	Min float64
+	Max float64
+	// Values are the valid values of strings and list items, any value is
+	// valid when empty
+	Values []string
+}
+
+// atLeast returns a numeric spec with a lower bound
+func atLeast(t topicConfigType, min float64) topicConfigSpec {
+	return topicConfigSpec{Type: t, Min: min, Max: math.Inf(1)}
+}
+
+// between returns a numeric spec with lower and upper bounds
+func between(t topicConfigType, min float64, max float64) topicConfigSpec {
+	return topicConfigSpec{Type: t, Min: min, Max: max}
+}
+
+// topicConfigs is the catalogue of the Kafka topic configs, see
+// https://kafka.apache.org/documentation/#topicconfigs
+var topicConfigs = map[string]topicConfigSpec{
+	"cleanup.policy":                          {Type: topicConfigList, Values: []string{"compact", "delete"}},
+	"compression.gzip.level":                  between(topicConfigInt, -1, 9),
+	"compression.lz4.level":                   between(topicConfigInt, 1, 17),
+	"compression.type":                        {Type: topicConfigString, Values: []string{"uncompressed", "zstd", "lz4", "snappy", "gzip", "producer"}},
+	"compression.zstd.level":                  between(topicConfigInt, -131072, 22),
+	"delete.retention.ms":                     atLeast(topicConfigLong, 0),
+	"file.delete.delay.ms":                    atLeast(topicConfigLong, 0),
+	"flush.messages":                          atLeast(topicConfigLong, 1),
+	"flush.ms":                                atLeast(topicConfigLong, 0),
+	"follower.replication.throttled.replicas": {Type: topicConfigString},
+	"index.interval.bytes":                    atLeast(topicConfigInt, 0),
+	"leader.replication.throttled.replicas":   {Type: topicConfigString},
+	"local.retention.bytes":                   atLeast(topicConfigLong, -2),
+	"local.retention.ms":                      atLeast(topicConfigLong, -2),
+	"max.compaction.lag.ms":                   atLeast(topicConfigLong, 1),
+	"max.message.bytes":                       atLeast(topicConfigInt, 0),
+	"message.downconversion.enable":           {Type: topicConfigBoolean},
+	"message.format.version":                  {Type: topicConfigString},
+	"message.timestamp.after.max.ms":          atLeast(topicConfigLong, 0),
+	"message.timestamp.before.max.ms":         atLeast(topicConfigLong, 0),
+	"message.timestamp.difference.max.ms":     atLeast(topicConfigLong, 0),
+	"message.timestamp.type":                  {Type: topicConfigString, Values: []string{"CreateTime", "LogAppendTime"}},
+	"min.cleanable.dirty.ratio":               between(topicConfigDouble, 0, 1),
+	"min.compaction.lag.ms":                   atLeast(topicConfigLong, 0),
+	"min.insync.replicas":                     atLeast(topicConfigInt, 1),
+	"preallocate":                             {Type: topicConfigBoolean},
+	"remote.log.copy.disable":                 {Type: topicConfigBoolean},
+	"remote.log.delete.on.disable":            {Type: topicConfigBoolean},
+	"remote.storage.enable":                   {Type: topicConfigBoolean},
+	"retention.bytes":                         atLeast(topicConfigLong, math.Inf(-1)),
+	"retention.ms":                            atLeast(topicConfigLong, -1),
+	"segment.bytes":                           atLeast(topicConfigInt, 14),
+	"segment.index.bytes":                     atLeast(topicConfigInt, 4),
+	"segment.jitter.ms":                       atLeast(topicConfigLong, 0),
+	"segment.ms":                              atLeast(topicConfigLong, 1),
+	"unclean.leader.election.enable":          {Type: topicConfigBoolean},
+}
+
+// validateTopicConfig returns an error when the value is not valid for the
+// config. Unknown configs are not validated.
+func validateTopicConfig(name string, value string) error {
+	spec, ok := topicConfigs[name]
+	if !ok {
+		return nil
+	}
+
+	switch spec.Type {
+	case topicConfigBoolean:
+		if !strings.EqualFold(value, "true") && !strings.EqualFold(value, "false") {
+			return fmt.Errorf("%q is not a boolean, must be true or false", value)
+		}
+	case topicConfigInt, topicConfigLong:
+		bitSize := 64
+		if spec.Type == topicConfigInt {
+			bitSize = 32
+		}
+		number, err := strconv.ParseInt(value, 10, bitSize)
+		if err != nil {
+			return fmt.Errorf("%q is not a %d bit integer", value, bitSize)
+		}
+		return validateTopicConfigRange(value, float64(number), spec)
+	case topicConfigDouble:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		return validateTopicConfigRange(value, number, spec)
+	case topicConfigList:
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			if len(spec.Values) > 0 && !containsString(item, spec.Values) {
+				return fmt.Errorf("%q is not a valid value, must be a comma separated list of: %s", item, strings.Join(spec.Values, ", "))
+			}
+		}
+	default:
+		if len(spec.Values) > 0 && !containsString(value, spec.Values) {
+			return fmt.Errorf("%q is not a valid value, must be one of: %s", value, strings.Join(spec.Values, ", "))
+		}
+	}
+	return nil
+}
+
+// validateTopicConfigRange returns an error when the number is out of the
+// bounds of the spec
+func validateTopicConfigRange(value string, number float64, spec topicConfigSpec) error {
+	if number < spec.Min {
+		return fmt.Errorf("%s must be at least %s", value, strconv.FormatFloat(spec.Min, 'f', -1, 64))
+	}
+	if number > spec.Max {
+		return fmt.Errorf("%s must be at most %s", value, strconv.FormatFloat(spec.Max, 'f', -1, 64))
+	}
+	return nil
+}
+
+// topicConfigValidator validates the configuration of a topic against the
+// Kafka topic configs catalogue. Unknown keys only warn, as brokers may
+// support configs newer than the catalogue or from vendor extensions.
+type topicConfigValidator struct{}
+
+var _ validator.Map = topicConfigValidator{}
+
+func (v topicConfigValidator) Description(ctx context.Context) string {
+	return "Validates the keys and values against the Kafka topic configs"
+}
+
+func (v topicConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v topicConfigValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	elements := req.ConfigValue.Elements()
+	names := make([]string, 0, len(elements))
+	for name := range elements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, ok := elements[name].(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if containsString(name, throttleConfigs) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Reserved topic configuration",
+				fmt.Sprintf("%s is set while partitions are reassigned, use reassignment_throttle instead.", name),
+			)
+			continue
+		}
+		if _, ok := topicConfigs[name]; !ok {
+			resp.Diagnostics.AddAttributeWarning(
+				req.Path.AtMapKey(name),
+				"Unknown topic configuration",
+				fmt.Sprintf("%s is not a known Kafka topic configuration, check it for typos. The broker rejects it during apply if it doesn't support it.", name),
+			)
+			continue
+		}
+		if err := validateTopicConfig(name, value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Invalid topic configuration",
+				fmt.Sprintf("Invalid value for %s: %s", name, err),
+			)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTopicConfig(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		err   bool
+	}{
+		{"cleanup.policy", "delete", false},
+		{"cleanup.policy", "compact,delete", false},
+		{"cleanup.policy", "compact, delete", false},
+		{"cleanup.policy", "delet", true},
+		{"compression.type", "zstd", false},
+		{"compression.type", "brotli", true},
+		{"message.timestamp.type", "LogAppendTime", false},
+		{"message.timestamp.type", "logappendtime", true},
+		{"preallocate", "TRUE", false},
+		{"preallocate", "yes", true},
+		{"retention.ms", "-1", false},
+		{"retention.ms", "-2", true},
+		{"retention.ms", "1h", true},
+		{"retention.bytes", "-1", false},
+		{"min.insync.replicas", "2", false},
+		{"min.insync.replicas", "0", true},
+		{"max.message.bytes", "2147483648", true},
+		{"segment.ms", "9223372036854775807", false},
+		{"min.cleanable.dirty.ratio", "0.5", false},
+		{"min.cleanable.dirty.ratio", "1.5", true},
+		{"compression.zstd.level", "23", true},
+		{"leader.replication.throttled.replicas", "0:1,1:2", false},
+		// Unknown configs are not validated
+		{"retention.msec", "anything", false},
+	}
+
+	for _, tc := range testCases {
+		err := validateTopicConfig(tc.name, tc.value)
+		if tc.err {
+			assert.Error(t, err, "%s = %q should be invalid", tc.name, tc.value)
+		} else {
+			assert.NoError(t, err, "%s = %q should be valid", tc.name, tc.value)
+		}
+	}
+}
+
+func TestTopicConfigValidator(t *testing.T) {
+	assert := assert.New(t)
+
+	req := validator.MapRequest{
+		Path: path.Root("configuration"),
+		ConfigValue: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"retention.msec":                        types.StringValue("3600000"),
+			"cleanup.policy":                        types.StringValue("delet"),
+			"compression.type":                      types.StringValue("zstd"),
+			"segment.ms":                            types.StringUnknown(),
+			"leader.replication.throttled.replicas": types.StringValue("*"),
+		}),
+	}
+	resp := &validator.MapResponse{}
+	topicConfigValidator{}.ValidateMap(context.Background(), req, resp)
+
+	assert.Equal(1, resp.Diagnostics.WarningsCount())
+	assert.Equal(2, resp.Diagnostics.ErrorsCount())
+	assert.Equal("Unknown topic configuration", resp.Diagnostics.Warnings()[0].Summary())
+	assert.Equal("Invalid topic configuration", resp.Diagnostics.Errors()[0].Summary())
+	assert.Equal("Reserved topic configuration", resp.Diagnostics.Errors()[1].Summary())
+}
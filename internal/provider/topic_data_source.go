@@ -3,12 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol/metadata"
 	"github.com/segmentio/topicctl/pkg/admin"
 )
 
@@ -26,12 +29,22 @@ type topicDataSource struct {
 
 // TopicDataSourceModel describes the data source data model.
 type topicDataSourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	Partitions        types.Int64  `tfsdk:"partitions"`
-	ReplicationFactor types.Int64  `tfsdk:"replication_factor"`
-	Version           types.Int64  `tfsdk:"version"`
-	Config            types.Map    `tfsdk:"configuration"`
+	ID                types.String               `tfsdk:"id"`
+	Name              types.String               `tfsdk:"name"`
+	Partitions        types.Int64                `tfsdk:"partitions"`
+	ReplicationFactor types.Int64                `tfsdk:"replication_factor"`
+	Version           types.Int64                `tfsdk:"version"`
+	Config            types.Map                  `tfsdk:"configuration"`
+	PartitionDetails  []topicDataSourcePartition `tfsdk:"partition_details"`
+}
+
+// topicDataSourcePartition describes each partition of the topic.
+type topicDataSourcePartition struct {
+	ID              types.Int64   `tfsdk:"id"`
+	Leader          types.Int64   `tfsdk:"leader"`
+	Replicas        []types.Int64 `tfsdk:"replicas"`
+	ISR             []types.Int64 `tfsdk:"isr"`
+	OfflineReplicas []types.Int64 `tfsdk:"offline_replicas"`
 }
 
 func (d *topicDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -67,6 +80,37 @@ func (d *topicDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				ElementType:         types.StringType,
 				Computed:            true,
 			},
+			"partition_details": schema.ListNestedAttribute{
+				MarkdownDescription: "Topic partitions, sorted by ID",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Partition ID",
+							Computed:            true,
+						},
+						"leader": schema.Int64Attribute{
+							MarkdownDescription: "ID of the leader broker, -1 when the partition has no leader",
+							Computed:            true,
+						},
+						"replicas": schema.ListAttribute{
+							MarkdownDescription: "IDs of the replica brokers",
+							ElementType:         types.Int64Type,
+							Computed:            true,
+						},
+						"isr": schema.ListAttribute{
+							MarkdownDescription: "IDs of the in-sync replica brokers",
+							ElementType:         types.Int64Type,
+							Computed:            true,
+						},
+						"offline_replicas": schema.ListAttribute{
+							MarkdownDescription: "IDs of the offline replica brokers",
+							ElementType:         types.Int64Type,
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -107,17 +151,29 @@ func (d *topicDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	replicationFactor, err := replicaCount(topicInfo)
+	partitionDetails, err := describePartitions(ctx, d.client, topicInfo.Name)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get replica count, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read topic metadata, got error: %s", err))
 		return
 	}
 
+	// Report non-uniform replication, which happens on interrupted
+	// reassignments, instead of failing to read the topic
+	replicationFactor, err := replicaCount(topicInfo)
+	if err != nil {
+		replicationFactor = maxReplicaCount(topicInfo)
+		resp.Diagnostics.AddWarning(
+			"Non-uniform replication",
+			fmt.Sprintf("Topic %s: %s. The replication_factor is set to the highest replica count %d, see partition_details for each partition.", topicInfo.Name, err, replicationFactor),
+		)
+	}
+
 	data.ID = types.StringValue(topicInfo.Name)
 	data.Name = types.StringValue(topicInfo.Name)
 	data.Partitions = types.Int64Value(int64(len(topicInfo.Partitions)))
 	data.ReplicationFactor = types.Int64Value(int64(replicationFactor))
 	data.Version = types.Int64Value(int64(topicInfo.Version))
+	data.PartitionDetails = partitionDetails
 
 	configElement := make(map[string]attr.Value)
 	for k, v := range topicInfo.Config {
@@ -135,3 +191,67 @@ func (d *topicDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// describePartitions returns the details of the topic partitions, sorted by
+// ID. They come from the raw metadata response, as the topic info lacks the
+// offline replicas and kafka-go reports the brokers missing from the metadata,
+// such as offline ones, as broker 0.
+func describePartitions(ctx context.Context, client *admin.BrokerAdminClient, topic string) ([]topicDataSourcePartition, error) {
+	kafkaClient := client.GetConnector().KafkaClient
+	transport := kafkaClient.Transport
+	if transport == nil {
+		transport = kafka.DefaultTransport
+	}
+	protoResp, err := transport.RoundTrip(ctx, kafkaClient.Addr, &metadata.Request{
+		TopicNames: []string{topic},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return partitionDetails(protoResp.(*metadata.Response), topic), nil
+}
+
+// partitionDetails returns the details of the topic partitions in the
+// metadata response, sorted by ID
+func partitionDetails(metadataResp *metadata.Response, topic string) []topicDataSourcePartition {
+	details := []topicDataSourcePartition{}
+	for _, t := range metadataResp.Topics {
+		if t.Name != topic {
+			continue
+		}
+		for _, partition := range t.Partitions {
+			details = append(details, topicDataSourcePartition{
+				ID: types.Int64Value(int64(partition.PartitionIndex)),
+				// Kafka returns -1 when the partition has no leader
+				Leader:          types.Int64Value(int64(partition.LeaderID)),
+				Replicas:        brokerIDValues(partition.ReplicaNodes),
+				ISR:             brokerIDValues(partition.IsrNodes),
+				OfflineReplicas: brokerIDValues(partition.OfflineReplicas),
+			})
+		}
+	}
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID.ValueInt64() < details[j].ID.ValueInt64()
+	})
+	return details
+}
+
+// brokerIDValues returns the broker IDs as values
+func brokerIDValues(ids []int32) []types.Int64 {
+	values := []types.Int64{}
+	for _, id := range ids {
+		values = append(values, types.Int64Value(int64(id)))
+	}
+	return values
+}
+
+// maxReplicaCount returns the highest replica count across the partitions
+func maxReplicaCount(topicInfo admin.TopicInfo) int {
+	count := 0
+	for _, p := range topicInfo.Partitions {
+		if len(p.Replicas) > count {
+			count = len(p.Replicas)
+		}
+	}
+	return count
+}
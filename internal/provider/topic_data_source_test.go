@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/topicctl/pkg/admin"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccTopicDataSource(t *testing.T) {
@@ -20,12 +24,76 @@ func TestAccTopicDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.kafka_topic.test", "name", existingTopic),
 					resource.TestCheckResourceAttr("data.kafka_topic.test", "partitions", "1"),
 					resource.TestCheckResourceAttr("data.kafka_topic.test", "replication_factor", "1"),
+					resource.TestCheckResourceAttr("data.kafka_topic.test", "partition_details.#", "1"),
+					resource.TestCheckResourceAttr("data.kafka_topic.test", "partition_details.0.id", "0"),
+					resource.TestCheckResourceAttr("data.kafka_topic.test", "partition_details.0.leader", "1"),
+					resource.TestCheckResourceAttr("data.kafka_topic.test", "partition_details.0.replicas.#", "1"),
+					resource.TestCheckResourceAttr("data.kafka_topic.test", "partition_details.0.replicas.0", "1"),
+					resource.TestCheckResourceAttr("data.kafka_topic.test", "partition_details.0.isr.#", "1"),
+					resource.TestCheckResourceAttr("data.kafka_topic.test", "partition_details.0.offline_replicas.#", "0"),
 				),
 			},
 		},
 	})
 }
 
+func TestMaxReplicaCount(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, maxReplicaCount(admin.TopicInfo{}))
+	assert.Equal(3, maxReplicaCount(admin.TopicInfo{
+		Partitions: []admin.PartitionInfo{
+			{ID: 0, Replicas: []int{1, 2}},
+			{ID: 1, Replicas: []int{1, 2, 3}},
+			{ID: 2, Replicas: []int{2}},
+		},
+	}))
+}
+
+func TestPartitionDetails(t *testing.T) {
+	assert := assert.New(t)
+
+	metadataResp := &metadata.Response{
+		Topics: []metadata.ResponseTopic{
+			{Name: "other", Partitions: []metadata.ResponsePartition{
+				{PartitionIndex: 0, LeaderID: 1, ReplicaNodes: []int32{1}, IsrNodes: []int32{1}},
+			}},
+			{Name: "test", Partitions: []metadata.ResponsePartition{
+				// Leaderless partition, its only replica is offline
+				{PartitionIndex: 2, LeaderID: -1, ReplicaNodes: []int32{3}, IsrNodes: []int32{}, OfflineReplicas: []int32{3}},
+				// Offline replica of a broker missing from the metadata
+				{PartitionIndex: 1, LeaderID: 1, ReplicaNodes: []int32{1, 3}, IsrNodes: []int32{1}, OfflineReplicas: []int32{3}},
+				{PartitionIndex: 0, LeaderID: 2, ReplicaNodes: []int32{2, 1}, IsrNodes: []int32{2, 1}},
+			}},
+		},
+	}
+
+	assert.Equal([]topicDataSourcePartition{
+		{
+			ID:              types.Int64Value(0),
+			Leader:          types.Int64Value(2),
+			Replicas:        []types.Int64{types.Int64Value(2), types.Int64Value(1)},
+			ISR:             []types.Int64{types.Int64Value(2), types.Int64Value(1)},
+			OfflineReplicas: []types.Int64{},
+		},
+		{
+			ID:              types.Int64Value(1),
+			Leader:          types.Int64Value(1),
+			Replicas:        []types.Int64{types.Int64Value(1), types.Int64Value(3)},
+			ISR:             []types.Int64{types.Int64Value(1)},
+			OfflineReplicas: []types.Int64{types.Int64Value(3)},
+		},
+		{
+			ID:              types.Int64Value(2),
+			Leader:          types.Int64Value(-1),
+			Replicas:        []types.Int64{types.Int64Value(3)},
+			ISR:             []types.Int64{},
+			OfflineReplicas: []types.Int64{types.Int64Value(3)},
+		},
+	}, partitionDetails(metadataResp, "test"))
+	assert.Equal([]topicDataSourcePartition{}, partitionDetails(metadataResp, "missing"))
+}
+
 func testAccTopicDataSourceConfig(name string) string {
 	return fmt.Sprintf(providerConfig+`
 data "kafka_topic" "test" {
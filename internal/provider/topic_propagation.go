@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// propagationPollInterval is how often we check whether a topic change reached
+// every broker
+var propagationPollInterval = time.Second
+
+var errPropagationTimeout = errors.New("timed out waiting for the topic change to propagate to all brokers")
+
+// waitForTopicCreated polls the metadata of every broker until all of them
+// return the topic with a leader for each partition, or the context deadline
+// expires
+func waitForTopicCreated(ctx context.Context, client *admin.BrokerAdminClient, topic string) error {
+	return waitForTopicPropagation(ctx, client, topic, topicCreated)
+}
+
+// waitForTopicDeleted polls the metadata of every broker until none of them
+// return the topic, or the context deadline expires
+func waitForTopicDeleted(ctx context.Context, client *admin.BrokerAdminClient, topic string) error {
+	return waitForTopicPropagation(ctx, client, topic, topicDeleted)
+}
+
+// waitForTopicPropagation polls the metadata of every broker until the topic
+// is in the desired state on all of them
+func waitForTopicPropagation(ctx context.Context, client *admin.BrokerAdminClient, topic string, propagated func([]kafka.Topic, string) bool) error {
+	var lastErr error
+	for {
+		pending, err := brokersPendingPropagation(ctx, client, topic, propagated)
+		if err == nil && len(pending) == 0 {
+			return nil
+		}
+		if err != nil {
+			// Brokers may fail to answer while the change is applied, so
+			// we retry until the deadline
+			lastErr = err
+			tflog.Debug(ctx, fmt.Sprintf("Unable to check topic %s propagation, got error: %s", topic, err))
+		} else {
+			lastErr = fmt.Errorf("pending on brokers %v", pending)
+			tflog.Info(ctx, fmt.Sprintf("Waiting for topic %s change to propagate to brokers %v", topic, pending))
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("%w: %s", errPropagationTimeout, lastErr)
+			}
+			return ctx.Err()
+		case <-time.After(propagationPollInterval):
+		}
+	}
+}
+
+// brokersPendingPropagation returns the IDs of the brokers where the topic is
+// not in the desired state yet
+func brokersPendingPropagation(ctx context.Context, client *admin.BrokerAdminClient, topic string, propagated func([]kafka.Topic, string) bool) ([]int, error) {
+	kafkaClient := client.GetConnector().KafkaClient
+	clusterResp, err := kafkaClient.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{topic}})
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []int{}
+	for _, broker := range clusterResp.Brokers {
+		brokerResp, err := kafkaClient.Metadata(ctx, &kafka.MetadataRequest{
+			Addr:   kafka.TCP(net.JoinHostPort(broker.Host, strconv.Itoa(broker.Port))),
+			Topics: []string{topic},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("broker %d: %w", broker.ID, err)
+		}
+		if !propagated(brokerResp.Topics, topic) {
+			pending = append(pending, broker.ID)
+		}
+	}
+	return pending, nil
+}
+
+// topicCreated returns whether the topic is in the metadata, with a leader for
+// each partition
+func topicCreated(topics []kafka.Topic, name string) bool {
+	for _, topic := range topics {
+		if topic.Name != name {
+			continue
+		}
+		if topic.Error != nil || len(topic.Partitions) == 0 {
+			return false
+		}
+		for _, partition := range topic.Partitions {
+			if partition.Error != nil || partitionLeaderID(partition) < 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// topicDeleted returns whether the topic is missing from the metadata
+func topicDeleted(topics []kafka.Topic, name string) bool {
+	for _, topic := range topics {
+		if topic.Name == name && !errors.Is(topic.Error, kafka.UnknownTopicOrPartition) {
+			return false
+		}
+	}
+	return true
+}
+
+// partitionLeaderID returns the ID of the partition leader, or -1 when it has
+// no leader. kafka-go returns an empty broker when the leader is not one of
+// the known brokers.
+func partitionLeaderID(partition kafka.Partition) int {
+	if partition.Leader.Host == "" {
+		return -1
+	}
+	return partition.Leader.ID
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"testing"
+
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicCreated(t *testing.T) {
+	assert := assert.New(t)
+
+	leader := kafka.Broker{ID: 1, Host: "localhost", Port: 9092}
+	assert.False(topicCreated([]kafka.Topic{}, "test"), "Missing topics are not created")
+	assert.False(topicCreated([]kafka.Topic{
+		{Name: "test", Error: kafka.LeaderNotAvailable},
+	}, "test"), "Topics with errors are not created")
+	assert.False(topicCreated([]kafka.Topic{
+		{Name: "test", Partitions: []kafka.Partition{
+			{ID: 0, Leader: leader},
+			{ID: 1, Leader: kafka.Broker{}},
+		}},
+	}, "test"), "Partitions without a leader are not created")
+	assert.False(topicCreated([]kafka.Topic{
+		{Name: "test", Partitions: []kafka.Partition{
+			{ID: 0, Leader: leader, Error: kafka.LeaderNotAvailable},
+		}},
+	}, "test"), "Partitions with errors are not created")
+	assert.True(topicCreated([]kafka.Topic{
+		{Name: "other"},
+		{Name: "test", Partitions: []kafka.Partition{
+			{ID: 0, Leader: leader},
+			{ID: 1, Leader: leader},
+		}},
+	}, "test"))
+}
+
+func TestTopicDeleted(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(topicDeleted([]kafka.Topic{}, "test"))
+	assert.True(topicDeleted([]kafka.Topic{
+		{Name: "test", Error: kafka.UnknownTopicOrPartition},
+	}, "test"))
+	assert.True(topicDeleted([]kafka.Topic{{Name: "other"}}, "test"))
+	assert.False(topicDeleted([]kafka.Topic{{Name: "test"}}, "test"))
+	assert.False(topicDeleted([]kafka.Topic{
+		{Name: "test", Error: kafka.LeaderNotAvailable},
+	}, "test"), "Topics with other errors may still exist")
+}
+
+func TestPartitionLeaderID(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, partitionLeaderID(kafka.Partition{Leader: kafka.Broker{ID: 0, Host: "localhost", Port: 9092}}))
+	assert.Equal(-1, partitionLeaderID(kafka.Partition{}))
+}
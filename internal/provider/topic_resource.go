@@ -2,9 +2,16 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -12,30 +19,39 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/pecigonzalo/terraform-provider-kafka/internal/modifier"
 	kafka "github.com/segmentio/kafka-go"
 	"github.com/segmentio/topicctl/pkg/admin"
-	"github.com/segmentio/topicctl/pkg/apply/assigners"
-	"github.com/segmentio/topicctl/pkg/apply/extenders"
-	"github.com/segmentio/topicctl/pkg/apply/pickers"
+	"github.com/segmentio/topicctl/pkg/config"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var (
-	_ resource.Resource                = &topicResource{}
-	_ resource.ResourceWithConfigure   = &topicResource{}
-	_ resource.ResourceWithImportState = &topicResource{}
+	_ resource.Resource                   = &topicResource{}
+	_ resource.ResourceWithConfigure      = &topicResource{}
+	_ resource.ResourceWithImportState    = &topicResource{}
+	_ resource.ResourceWithValidateConfig = &topicResource{}
 )
 
+const (
+	destroyBehaviorDelete  = "delete"
+	destroyBehaviorAbandon = "abandon"
+)
+
+// destroyBehaviors are the valid values of destroy_behavior
+var destroyBehaviors = []string{destroyBehaviorDelete, destroyBehaviorAbandon}
+
 func NewTopicResource() resource.Resource {
 	return &topicResource{}
 }
 
 // topicResource defines the resource implementation.
 type topicResource struct {
-	client *admin.BrokerAdminClient
+	client                 *admin.BrokerAdminClient
+	protectedTopicPatterns []*regexp.Regexp
 }
 
 // TopicResourceModel describes the resource data model.
@@ -45,13 +61,45 @@ type TopicResourceModel struct {
 	Partitions        types.Int64  `tfsdk:"partitions"`
 	ReplicationFactor types.Int64  `tfsdk:"replication_factor"`
 	Config            types.Map    `tfsdk:"configuration"`
+	ReplicaAssignment types.List   `tfsdk:"replica_assignment"`
+	EffectiveConfig   types.Map    `tfsdk:"effective_configuration"`
+
+	RecreateOnPartitionDecrease types.Bool                 `tfsdk:"recreate_on_partition_decrease"`
+	CancelReassignmentOnTimeout types.Bool                 `tfsdk:"cancel_reassignment_on_timeout"`
+	ReassignmentThrottle        *ReassignmentThrottleModel `tfsdk:"reassignment_throttle"`
+	Placement                   *PlacementModel            `tfsdk:"placement"`
+	Timeouts                    timeouts.Value             `tfsdk:"timeouts"`
+	DeletionProtection          types.Bool                 `tfsdk:"deletion_protection"`
+	DestroyBehavior             types.String               `tfsdk:"destroy_behavior"`
+}
+
+// ReassignmentThrottleModel describes the replication throttle applied while
+// partitions are reassigned
+type ReassignmentThrottleModel struct {
+	Rate types.Int64 `tfsdk:"rate"`
+}
+
+// PlacementModel describes how the replicas of new partitions and replicas are
+// placed across brokers
+type PlacementModel struct {
+	Strategy              types.String `tfsdk:"strategy"`
+	Picker                types.String `tfsdk:"picker"`
+	StaticRackAssignments types.List   `tfsdk:"static_rack_assignments"`
+}
+
+// picker returns the configured picker method, or the given default one
+func (m *PlacementModel) picker(defaultPicker config.PickerMethod) string {
+	if m.Picker.IsNull() {
+		return string(defaultPicker)
+	}
+	return m.Picker.ValueString()
 }
 
 func (r *topicResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_topic"
 }
 
-func (r *topicResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *topicResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Kafka Topic resource",
@@ -73,12 +121,23 @@ func (r *topicResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"partitions": schema.Int64Attribute{
-				MarkdownDescription: "Topic partitions count",
+				MarkdownDescription: "Topic partitions count. Partitions can't be reduced, unless `recreate_on_partition_decrease` is set",
 				Required:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
+					modifier.Int64RejectDecrease(path.Root("recreate_on_partition_decrease")),
 				},
 			},
+			"replica_assignment": schema.ListAttribute{
+				MarkdownDescription: "Brokers assigned to each partition, in partition order. The first broker of each partition is its preferred leader. " +
+					"Must have `partitions` entries of `replication_factor` brokers each",
+				ElementType: types.ListType{ElemType: types.Int64Type},
+				Optional:    true,
+			},
+			"recreate_on_partition_decrease": schema.BoolAttribute{
+				MarkdownDescription: "Replace the topic, deleting all its data, when `partitions` is decreased instead of failing the plan (default: false)",
+				Optional:            true,
+			},
 			"replication_factor": schema.Int64Attribute{
 				MarkdownDescription: "Topic replication factor count",
 				Required:            true,
@@ -87,10 +146,15 @@ func (r *topicResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"configuration": schema.MapAttribute{
-				MarkdownDescription: "Configuration",
-				ElementType:         types.StringType,
-				Optional:            true,
-				Computed:            true,
+				MarkdownDescription: "Configuration set on the topic. Configuration inherited from the brokers is not tracked, see `effective_configuration`. " +
+					"Values are validated against the [Kafka topic configs](https://kafka.apache.org/documentation/#topicconfigs) during plan, and unknown keys produce a warning. " +
+					"The replication throttle configs are managed by `reassignment_throttle` and can't be set",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Map{
+					topicConfigValidator{},
+				},
 				PlanModifiers: []planmodifier.Map{
 					mapplanmodifier.UseStateForUnknown(),
 					modifier.MapDefaultValue(types.MapValueMust(
@@ -99,6 +163,65 @@ func (r *topicResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					)),
 				},
 			},
+			"effective_configuration": schema.MapAttribute{
+				MarkdownDescription: "Full configuration resolved for the topic, including the values inherited from the brokers and the defaults",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"cancel_reassignment_on_timeout": schema.BoolAttribute{
+				MarkdownDescription: "Cancel an in progress partition reassignment when it does not complete before the timeout, reverting the partitions to their previous replicas (default: false)",
+				Optional:            true,
+			},
+			"reassignment_throttle": schema.SingleNestedAttribute{
+				MarkdownDescription: "Replication throttle applied while partitions are reassigned. The throttles are removed once the reassignment completes",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"rate": schema.Int64Attribute{
+						MarkdownDescription: "Maximum replication rate in bytes per second for each broker involved in the reassignment",
+						Required:            true,
+					},
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to delete the topic, including when it is replaced. " +
+					"Must be set to false and applied before the topic can be destroyed (default: false)",
+				Optional: true,
+			},
+			"destroy_behavior": schema.StringAttribute{
+				MarkdownDescription: "What happens to the topic when the resource is destroyed, one of: `" + strings.Join(destroyBehaviors, "`, `") + "`. " +
+					"`abandon` only removes the resource from the state, leaving the topic and its data in the cluster. " +
+					"Must be applied before the resource is removed from the configuration (default: delete)",
+				Optional: true,
+			},
+			"placement": schema.SingleNestedAttribute{
+				MarkdownDescription: "Placement of the replicas when partitions or replicas are added to the topic. " +
+					"When unset, the replicas of new partitions are balanced across brokers and new replicas are placed across racks",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"strategy": schema.StringAttribute{
+						MarkdownDescription: "Placement strategy, one of: `" + strings.Join(placementStrategies, "`, `") + "`. " +
+							"The `static` strategy places the replicas as set in `replica_assignment`",
+						Required: true,
+					},
+					"picker": schema.StringAttribute{
+						MarkdownDescription: "Method used to break ties between brokers, one of: `" + strings.Join(pickerMethods, "`, `") + "` (default: `cluster-use` when adding replicas, `randomized` when adding partitions)",
+						Optional:            true,
+					},
+					"static_rack_assignments": schema.ListAttribute{
+						MarkdownDescription: "Rack of each partition, in partition order. Required by the `static-in-rack` strategy",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -109,18 +232,133 @@ func (r *topicResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*admin.BrokerAdminClient)
+	data, ok := req.ProviderData.(*kafkaResourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *kafkaResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.protectedTopicPatterns = data.ProtectedTopicPatterns
+}
+
+func (r *topicResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TopicResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Placement != nil {
+		resp.Diagnostics.Append(validatePlacement(ctx, &data)...)
+	}
+	if !data.DestroyBehavior.IsNull() && !data.DestroyBehavior.IsUnknown() &&
+		!containsString(data.DestroyBehavior.ValueString(), destroyBehaviors) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("destroy_behavior"),
+			"Invalid destroy behavior",
+			fmt.Sprintf("%q is not a valid destroy behavior, must be one of: %s", data.DestroyBehavior.ValueString(), strings.Join(destroyBehaviors, ", ")),
+		)
+	}
+
+	if data.ReplicaAssignment.IsNull() || data.ReplicaAssignment.IsUnknown() ||
+		data.Partitions.IsUnknown() || data.ReplicationFactor.IsUnknown() {
+		return
+	}
+	replicas, diags := replicaAssignmentValue(ctx, data.ReplicaAssignment)
+	if diags.HasError() {
+		// Some of the brokers are not known yet
+		return
+	}
+	err := validateReplicaAssignment(replicas, int(data.Partitions.ValueInt64()), int(data.ReplicationFactor.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("replica_assignment"), "Invalid replica assignment", err.Error())
+	}
+}
+
+// validatePlacement checks the placement block values, and that the static
+// strategies have the assignments they need
+func validatePlacement(ctx context.Context, data *TopicResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	placement := data.Placement
+
+	strategy := placement.Strategy.ValueString()
+	if !placement.Strategy.IsUnknown() && !containsString(strategy, placementStrategies) {
+		diags.AddAttributeError(
+			path.Root("placement").AtName("strategy"),
+			"Invalid placement strategy",
+			fmt.Sprintf("%q is not a valid strategy, must be one of: %s", strategy, strings.Join(placementStrategies, ", ")),
+		)
+	}
+	if !placement.Picker.IsNull() && !placement.Picker.IsUnknown() && !containsString(placement.Picker.ValueString(), pickerMethods) {
+		diags.AddAttributeError(
+			path.Root("placement").AtName("picker"),
+			"Invalid placement picker",
+			fmt.Sprintf("%q is not a valid picker, must be one of: %s", placement.Picker.ValueString(), strings.Join(pickerMethods, ", ")),
+		)
+	}
+	if placement.Strategy.IsUnknown() {
+		return diags
+	}
+
+	isStatic := strategy == string(config.PlacementStrategyStatic)
+	if isStatic && data.ReplicaAssignment.IsNull() {
+		diags.AddAttributeError(
+			path.Root("replica_assignment"),
+			"Missing replica assignment",
+			"The static placement strategy requires replica_assignment to be set",
+		)
+	}
+	if !isStatic && !data.ReplicaAssignment.IsNull() {
+		diags.AddAttributeError(
+			path.Root("placement").AtName("strategy"),
+			"Invalid placement strategy",
+			"replica_assignment can only be combined with the static placement strategy",
+		)
+	}
+
+	isStaticInRack := strategy == string(config.PlacementStrategyStaticInRack)
+	if isStaticInRack != !placement.StaticRackAssignments.IsNull() {
+		diags.AddAttributeError(
+			path.Root("placement").AtName("static_rack_assignments"),
+			"Invalid static rack assignments",
+			"static_rack_assignments must be set only with the static-in-rack placement strategy",
+		)
+		return diags
+	}
+	if isStaticInRack && !placement.StaticRackAssignments.IsUnknown() && !data.Partitions.IsUnknown() &&
+		len(placement.StaticRackAssignments.Elements()) != int(data.Partitions.ValueInt64()) {
+		diags.AddAttributeError(
+			path.Root("placement").AtName("static_rack_assignments"),
+			"Invalid static rack assignments",
+			fmt.Sprintf("expected %d racks, one for each partition, got %d", data.Partitions.ValueInt64(), len(placement.StaticRackAssignments.Elements())),
+		)
+	}
+
+	return diags
+}
+
+// placementFor returns the placement strategy, picker and rack assignments to
+// use, falling back to the given defaults when they are not configured
+func placementFor(ctx context.Context, data *TopicResourceModel, defaultStrategy config.PlacementStrategy, defaultPicker config.PickerMethod) (string, string, []string, error) {
+	if data.Placement == nil {
+		return string(defaultStrategy), string(defaultPicker), nil, nil
+	}
+
+	rackAssignments := []string{}
+	if !data.Placement.StaticRackAssignments.IsNull() {
+		diags := data.Placement.StaticRackAssignments.ElementsAs(ctx, &rackAssignments, false)
+		if diags.HasError() {
+			return "", "", nil, fmt.Errorf("unable to read static_rack_assignments: %v", diags)
+		}
+	}
+	return data.Placement.Strategy.ValueString(), data.Placement.picker(defaultPicker), rackAssignments, nil
 }
 
 func (r *topicResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -133,14 +371,19 @@ func (r *topicResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel, diags := r.operationContext(ctx, data.Timeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
 	// Generate KafkaConfig
 	var configEntries []kafka.ConfigEntry
-	for k, v := range data.Config.Elements() {
+	for k, v := range configElements(data.Config) {
 		configEntries = append(configEntries, kafka.ConfigEntry{
-			ConfigName: k,
-			// TODO: Why do we have to do this ugly remove quotes?
-			// Lets try and fix this in the datamodel or similar
-			ConfigValue: v.String()[1 : len(v.String())-1],
+			ConfigName:  k,
+			ConfigValue: v,
 		})
 	}
 	topicConfig := kafka.TopicConfig{
@@ -149,6 +392,23 @@ func (r *topicResource) Create(ctx context.Context, req resource.CreateRequest,
 		ReplicationFactor: int(data.ReplicationFactor.ValueInt64()),
 		ConfigEntries:     configEntries,
 	}
+	if !data.ReplicaAssignment.IsNull() {
+		replicas, diags := replicaAssignmentValue(ctx, data.ReplicaAssignment)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Partitions and replication factor must be unset when the
+		// assignments are explicit
+		topicConfig.NumPartitions = -1
+		topicConfig.ReplicationFactor = -1
+		for partition, brokers := range replicas {
+			topicConfig.ReplicaAssignments = append(topicConfig.ReplicaAssignments, kafka.ReplicaAssignment{
+				Partition: partition,
+				Replicas:  brokers,
+			})
+		}
+	}
 
 	tflog.Info(ctx, fmt.Sprintf("Creating topic %s", data.Name.ValueString()))
 	createRequest := kafka.CreateTopicsRequest{
@@ -169,6 +429,16 @@ func (r *topicResource) Create(ctx context.Context, req resource.CreateRequest,
 	data.ID = data.Name
 	tflog.Trace(ctx, "Created topic")
 
+	// Wait for every broker to know the topic, so reads and dependent
+	// resources don't see stale metadata. Failing to confirm it only warns,
+	// as an error would taint the resource and the next apply would replace
+	// a topic that may already hold data.
+	if err := waitForTopicCreated(ctx, r.client, data.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddWarning("Client Error", fmt.Sprintf("Topic created, but unable to confirm it on all brokers, got error: %s", err))
+	}
+
+	r.refreshEffectiveConfig(ctx, data, &resp.Diagnostics)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -182,6 +452,13 @@ func (r *topicResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, cancel, diags := r.operationContext(ctx, data.Timeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
 	topicInfo, err := r.client.GetTopic(ctx, data.ID.ValueString(), true)
 	if err != nil {
 		switch err {
@@ -201,17 +478,28 @@ func (r *topicResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	// Only the configs set on the topic are tracked, so inherited broker
+	// configs don't show up as a diff
+	config, effectiveConfig, err := describeTopicConfig(ctx, r.client, topicInfo.Name)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read topic configuration, got error: %s", err))
+		return
+	}
+
 	data.Name = types.StringValue(topicInfo.Name)
 	data.Partitions = types.Int64Value(int64(len(topicInfo.Partitions)))
 	data.ReplicationFactor = types.Int64Value(int64(replicationFactor))
-	configElement := map[string]attr.Value{}
-	for k, v := range topicInfo.Config {
-		configElement[k] = types.StringValue(v)
+	data.Config = configMapValue(config)
+	data.EffectiveConfig = configMapValue(effectiveConfig)
+	// We only track the placement when it is managed explicitly
+	if !data.ReplicaAssignment.IsNull() {
+		assignment, diags := replicaAssignmentListValue(ctx, topicInfo)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.ReplicaAssignment = assignment
 	}
-	data.Config = types.MapValueMust(
-		types.StringType,
-		configElement,
-	)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -248,14 +536,39 @@ func (r *topicResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx, cancel, diags := r.operationContext(ctx, data.Timeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
 	if !data.Config.Equal(state.Config) {
 		tflog.Info(ctx, "Updating topic configuration")
-		err := r.updateConfig(ctx, data, req, resp)
+		err := r.updateConfig(ctx, state, data, req, resp)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update topic configuration, got error: %s", err))
 			return
 		}
 	}
+	if !data.ReplicaAssignment.IsNull() {
+		if !data.ReplicaAssignment.Equal(state.ReplicaAssignment) ||
+			!data.ReplicationFactor.Equal(state.ReplicationFactor) ||
+			!data.Partitions.Equal(state.Partitions) {
+			tflog.Info(ctx, "Updating topic replica assignment")
+			err := r.updateReplicaAssignment(ctx, data)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update topic replica assignment, got error: %s", err))
+				return
+			}
+		}
+
+		r.refreshEffectiveConfig(ctx, data, &resp.Diagnostics)
+
+		// Save updated data into Terraform state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 	if !data.ReplicationFactor.Equal(state.ReplicationFactor) {
 		tflog.Info(ctx, "Updating topic replication factor")
 		err := r.updateReplicationFactor(ctx, state, data, req, resp)
@@ -273,56 +586,106 @@ func (r *topicResource) Update(ctx context.Context, req resource.UpdateRequest,
 		}
 	}
 
+	r.refreshEffectiveConfig(ctx, data, &resp.Diagnostics)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *topicResource) updateConfig(ctx context.Context, data *TopicResourceModel, req resource.UpdateRequest, resp *resource.UpdateResponse) error {
-	// Generate KafkaConfig
-	var configEntries []kafka.ConfigEntry
-	for k, v := range data.Config.Elements() {
-		configEntries = append(configEntries, kafka.ConfigEntry{
-			ConfigName: k,
-			// TODO: Why do we have to do this ugly remove quotes?
-			// Lets try and fix this in the datamodel or similar
-			ConfigValue: v.String()[1 : len(v.String())-1],
-		})
+func (r *topicResource) updateConfig(ctx context.Context, state *TopicResourceModel, data *TopicResourceModel, req resource.UpdateRequest, resp *resource.UpdateResponse) error {
+	configs := configDiff(configElements(state.Config), configElements(data.Config))
+	if len(configs) == 0 {
+		return nil
 	}
 
-	alterConfigsRequest := kafka.AlterConfigsRequest{
-		Resources: []kafka.AlterConfigRequestResource{
+	incrementalAlterConfigsRequest := kafka.IncrementalAlterConfigsRequest{
+		Resources: []kafka.IncrementalAlterConfigsRequestResource{
 			{
 				ResourceType: kafka.ResourceTypeTopic,
 				ResourceName: data.Name.ValueString(),
-				Configs:      configEntriesToAlterConfigs(configEntries),
+				Configs:      configs,
 			},
 		},
 	}
 
-	clientResp, err := r.client.GetConnector().KafkaClient.AlterConfigs(ctx, &alterConfigsRequest)
+	tflog.Debug(ctx, fmt.Sprintf("Altering configs: %v", configs))
+
+	clientResp, err := r.client.GetConnector().KafkaClient.IncrementalAlterConfigs(ctx, &incrementalAlterConfigsRequest)
 	if err != nil {
 		return err
 	}
-	for _, v := range clientResp.Errors {
-		return v
+	for _, v := range clientResp.Resources {
+		if v.Error != nil {
+			return v.Error
+		}
 	}
 	return nil
 }
 
-// Generate a AlterConfigRequestConfig from ConfigEntry
-func configEntriesToAlterConfigs(
-	configEntries []kafka.ConfigEntry,
-) []kafka.AlterConfigRequestConfig {
-	apiConfigs := []kafka.AlterConfigRequestConfig{}
-	for _, entry := range configEntries {
+// refreshEffectiveConfig sets the effective_configuration of the topic once it
+// is created or updated. Failing to read it only warns, as the topic changes
+// are already applied and the next refresh reads it again.
+func (r *topicResource) refreshEffectiveConfig(ctx context.Context, data *TopicResourceModel, diags *diag.Diagnostics) {
+	_, effectiveConfig, err := describeTopicConfig(ctx, r.client, data.Name.ValueString())
+	if err != nil {
+		diags.AddWarning("Client Error", fmt.Sprintf("Unable to read topic effective configuration, got error: %s", err))
+		data.EffectiveConfig = types.MapNull(types.StringType)
+		return
+	}
+	data.EffectiveConfig = configMapValue(effectiveConfig)
+}
+
+// configElements returns the values of a configuration map as plain strings
+func configElements(config types.Map) map[string]string {
+	elements := map[string]string{}
+	for k, v := range config.Elements() {
+		if s, ok := v.(types.String); ok {
+			elements[k] = s.ValueString()
+		}
+	}
+	return elements
+}
+
+// configDiff generates the incremental operations needed to go from the
+// current configuration to the desired one. Keys that are no longer desired
+// are deleted, which reverts them to the broker default. The throttle configs
+// are left to the reassignments.
+func configDiff(
+	current map[string]string,
+	desired map[string]string,
+) []kafka.IncrementalAlterConfigsRequestConfig {
+	apiConfigs := []kafka.IncrementalAlterConfigsRequestConfig{}
+	for name, value := range desired {
+		if containsString(name, throttleConfigs) {
+			continue
+		}
+		if currentValue, ok := current[name]; ok && currentValue == value {
+			continue
+		}
 		apiConfigs = append(
 			apiConfigs,
-			kafka.AlterConfigRequestConfig{
-				Name:  entry.ConfigName,
-				Value: entry.ConfigValue,
+			kafka.IncrementalAlterConfigsRequestConfig{
+				Name:            name,
+				Value:           value,
+				ConfigOperation: kafka.ConfigOperationSet,
 			},
 		)
 	}
+	for name := range current {
+		if containsString(name, throttleConfigs) {
+			continue
+		}
+		if _, ok := desired[name]; !ok {
+			apiConfigs = append(
+				apiConfigs,
+				kafka.IncrementalAlterConfigsRequestConfig{
+					Name:            name,
+					ConfigOperation: kafka.ConfigOperationDelete,
+				},
+			)
+		}
+	}
+	sort.Slice(apiConfigs, func(i, j int) bool { return apiConfigs[i].Name < apiConfigs[j].Name })
 	return apiConfigs
 }
 
@@ -345,23 +708,7 @@ func (r *topicResource) updateReplicationFactor(ctx context.Context, state *Topi
 		return err
 	}
 
-	// Don't include the topic for this applier since the picker already considers
-	// broker placement within the topic and, also, the placement might change during
-	// the apply process.
-	nonAppliedTopics := []admin.TopicInfo{}
-	topics, err := r.client.GetTopics(ctx, nil, false)
-	if err != nil {
-		return err
-	}
-	for _, topic := range topics {
-		if topic.Name != data.Name.ValueString() {
-			nonAppliedTopics = append(
-				nonAppliedTopics,
-				topic,
-			)
-		}
-	}
-
+	currAssignments := admin.CopyAssignments(topicInfo.ToAssignments())
 	replicasWanted := data.ReplicationFactor.ValueInt64()
 	replicasPresent := state.ReplicationFactor.ValueInt64()
 
@@ -382,14 +729,53 @@ func (r *topicResource) updateReplicationFactor(ctx context.Context, state *Topi
 	topicInfo.Partitions = newPartitionsInfo
 	newAssignments := topicInfo.ToAssignments()
 
-	picker := pickers.NewClusterUsePicker(brokersInfo, nonAppliedTopics)
-	assigner := assigners.NewCrossRackAssigner(brokersInfo, picker)
-
-	assignments, err := assigner.Assign(data.Name.ValueString(), newAssignments)
+	strategy, method, rackAssignments, err := placementFor(ctx, data, config.PlacementStrategyCrossRack, config.PickerMethodClusterUse)
+	if err != nil {
+		return err
+	}
+	picker, err := placementPicker(ctx, r.client, data.Name.ValueString(), brokersInfo, method)
 	if err != nil {
 		return err
 	}
+	assigner, err := placementAssigner(strategy, brokersInfo, rackAssignments, picker)
+	if err != nil {
+		return err
+	}
+
+	assignments := newAssignments
+	if assigner != nil {
+		assignments, err = assigner.Assign(data.Name.ValueString(), newAssignments)
+		if err != nil {
+			return err
+		}
+	}
+
+	throttledTopic := false
+	throttledBrokers := []throttledBroker{}
+	if data.ReassignmentThrottle != nil {
+		throttledTopic, throttledBrokers, err = applyThrottles(ctx, r.client, data.Name.ValueString(), currAssignments, assignments, data.ReassignmentThrottle.Rate.ValueInt64())
+		if err != nil {
+			return errors.Join(err, removeThrottles(ctx, r.client, data.Name.ValueString(), throttledTopic, throttledBrokers))
+		}
+	}
+
+	err = r.reassignPartitions(ctx, data, assignments)
+	if errors.Is(err, errReassignmentTimeout) && !data.CancelReassignmentOnTimeout.ValueBool() {
+		// Removing the throttles now would let the ongoing reassignment
+		// saturate the brokers, so we leave them in place
+		if throttledTopic || len(throttledBrokers) > 0 {
+			tflog.Warn(ctx, fmt.Sprintf("Leaving replication throttles in place on topic %s and brokers %v until the reassignment completes", data.Name.ValueString(), throttledBrokers))
+		}
+		return err
+	}
+	// The reassignment may have used up the deadline, the throttles must be
+	// removed regardless
+	return errors.Join(err, removeThrottles(context.WithoutCancel(ctx), r.client, data.Name.ValueString(), throttledTopic, throttledBrokers))
+}
 
+// reassignPartitions applies the partition assignments and waits for the
+// reassignment to complete
+func (r *topicResource) reassignPartitions(ctx context.Context, data *TopicResourceModel, assignments []admin.PartitionAssignment) error {
 	apiAssignments := []kafka.AlterPartitionReassignmentsRequestAssignment{}
 	for _, assignment := range assignments {
 		apiAssignment := kafka.AlterPartitionReassignmentsRequestAssignment{
@@ -410,7 +796,7 @@ func (r *topicResource) updateReplicationFactor(ctx context.Context, state *Topi
 		return err
 	}
 	if clientResp.Error != nil {
-		return err
+		return clientResp.Error
 	}
 	if len(clientResp.PartitionResults) > 0 {
 		partErrors := []error{}
@@ -420,10 +806,26 @@ func (r *topicResource) updateReplicationFactor(ctx context.Context, state *Topi
 			}
 		}
 		if len(partErrors) > 0 {
-			return fmt.Errorf("errors changing replication factor: %s", partErrors)
+			return fmt.Errorf("errors reassigning partitions: %s", partErrors)
 		}
 	}
-	return nil
+
+	partitionIDs := []int{}
+	for _, assignment := range assignments {
+		partitionIDs = append(partitionIDs, assignment.ID)
+	}
+	err = waitForReassignment(ctx, r.client, data.Name.ValueString(), partitionIDs)
+	if errors.Is(err, errReassignmentTimeout) && data.CancelReassignmentOnTimeout.ValueBool() {
+		tflog.Warn(ctx, "Cancelling partition reassignment")
+		if cancelErr := cancelReassignment(context.WithoutCancel(ctx), r.client, data.Name.ValueString(), partitionIDs); cancelErr != nil {
+			return fmt.Errorf("%w, and cancelling it failed: %s", err, cancelErr)
+		}
+		return fmt.Errorf("%w, the reassignment was cancelled", err)
+	}
+	if errors.Is(err, errReassignmentTimeout) {
+		return fmt.Errorf("%w, the reassignment continues in the background", err)
+	}
+	return err
 }
 
 func containsId(id int, ids []int) bool {
@@ -488,12 +890,18 @@ func (r *topicResource) updatePartitions(ctx context.Context, state *TopicResour
 	}
 	extraPartitions := int(data.Partitions.ValueInt64()) - int(state.Partitions.ValueInt64())
 
-	picker := pickers.NewRandomizedPicker()
-	extender := extenders.NewBalancedExtender(
-		brokersInfo,
-		false,
-		picker,
-	)
+	strategy, method, rackAssignments, err := placementFor(ctx, data, config.PlacementStrategyAny, config.PickerMethodRandomized)
+	if err != nil {
+		return err
+	}
+	picker, err := placementPicker(ctx, r.client, data.Name.ValueString(), brokersInfo, method)
+	if err != nil {
+		return err
+	}
+	extender, err := placementExtender(strategy, brokersInfo, picker)
+	if err != nil {
+		return err
+	}
 	desiredAssignments, err := extender.Extend(
 		data.Name.ValueString(),
 		currAssignments,
@@ -502,6 +910,17 @@ func (r *topicResource) updatePartitions(ctx context.Context, state *TopicResour
 	if err != nil {
 		return err
 	}
+	if strategy == string(config.PlacementStrategyStaticInRack) {
+		// Move the new partitions to their static racks
+		assigner, err := placementAssigner(strategy, brokersInfo, rackAssignments, picker)
+		if err != nil {
+			return err
+		}
+		desiredAssignments, err = assigner.Assign(data.Name.ValueString(), desiredAssignments)
+		if err != nil {
+			return err
+		}
+	}
 	desiredAssignments = desiredAssignments[len(desiredAssignments)-extraPartitions:]
 
 	tflog.Info(ctx, fmt.Sprintf("Assignments: %v", desiredAssignments))
@@ -514,6 +933,61 @@ func (r *topicResource) updatePartitions(ctx context.Context, state *TopicResour
 	return nil
 }
 
+// updateReplicaAssignment moves the existing partitions to the desired brokers
+// and adds any new partition with its explicit assignment
+func (r *topicResource) updateReplicaAssignment(ctx context.Context, data *TopicResourceModel) error {
+	replicas, diags := replicaAssignmentValue(ctx, data.ReplicaAssignment)
+	if diags.HasError() {
+		return fmt.Errorf("unable to read replica_assignment: %v", diags)
+	}
+	desiredAssignments := admin.ReplicasToAssignments(replicas)
+
+	topicInfo, err := r.client.GetTopic(ctx, data.Name.ValueString(), false)
+	if err != nil {
+		return err
+	}
+	currAssignments := topicInfo.ToAssignments()
+	sort.Slice(currAssignments, func(i, j int) bool { return currAssignments[i].ID < currAssignments[j].ID })
+	if len(desiredAssignments) < len(currAssignments) {
+		return fmt.Errorf("partition count can't be reduced")
+	}
+
+	existingAssignments := desiredAssignments[:len(currAssignments)]
+	if len(admin.AssignmentsToUpdate(currAssignments, existingAssignments)) > 0 {
+		throttledTopic := false
+		throttledBrokers := []throttledBroker{}
+		if data.ReassignmentThrottle != nil {
+			throttledTopic, throttledBrokers, err = applyThrottles(ctx, r.client, data.Name.ValueString(), currAssignments, existingAssignments, data.ReassignmentThrottle.Rate.ValueInt64())
+			if err != nil {
+				return errors.Join(err, removeThrottles(ctx, r.client, data.Name.ValueString(), throttledTopic, throttledBrokers))
+			}
+		}
+
+		err = r.reassignPartitions(ctx, data, admin.AssignmentsToUpdate(currAssignments, existingAssignments))
+		if errors.Is(err, errReassignmentTimeout) && !data.CancelReassignmentOnTimeout.ValueBool() {
+			if throttledTopic || len(throttledBrokers) > 0 {
+				tflog.Warn(ctx, fmt.Sprintf("Leaving replication throttles in place on topic %s and brokers %v until the reassignment completes", data.Name.ValueString(), throttledBrokers))
+			}
+			return err
+		}
+		err = errors.Join(err, removeThrottles(context.WithoutCancel(ctx), r.client, data.Name.ValueString(), throttledTopic, throttledBrokers))
+		if err != nil {
+			return err
+		}
+	}
+
+	newAssignments := desiredAssignments[len(currAssignments):]
+	if len(newAssignments) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("Assignments: %v", newAssignments))
+		err = r.client.AddPartitions(ctx, data.Name.ValueString(), newAssignments)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *topicResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data *TopicResourceModel
 
@@ -524,6 +998,32 @@ func (r *topicResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// Abandoning leaves the data in place, so it is allowed for protected
+	// topics too
+	if data.DestroyBehavior.ValueString() == destroyBehaviorAbandon {
+		tflog.Warn(ctx, fmt.Sprintf("Abandoning topic %s, it is left in the cluster", data.Name.ValueString()))
+		resp.Diagnostics.AddWarning(
+			"Topic abandoned",
+			fmt.Sprintf("Topic %s was removed from the state without being deleted, as destroy_behavior is abandon. The topic and its data are left in the cluster.", data.Name.ValueString()),
+		)
+		return
+	}
+
+	if protected, reason := r.deletionProtected(data); protected {
+		resp.Diagnostics.AddError(
+			"Topic is protected from deletion",
+			fmt.Sprintf("Topic %s can't be deleted, %s.", data.Name.ValueString(), reason),
+		)
+		return
+	}
+
+	ctx, cancel, diags := r.operationContext(ctx, data.Timeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
 	clientResp, err := r.client.GetConnector().KafkaClient.DeleteTopics(ctx, &kafka.DeleteTopicsRequest{
 		Topics: []string{data.Name.ValueString()},
 	})
@@ -538,8 +1038,98 @@ func (r *topicResource) Delete(ctx context.Context, req resource.DeleteRequest,
 			return
 		}
 	}
+
+	// Wait for every broker to forget the topic, so it can be created again
+	// in the same apply. Failing to confirm it only warns, as an error would
+	// keep a topic that is already deleted in the state.
+	if err := waitForTopicDeleted(ctx, r.client, data.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddWarning("Client Error", fmt.Sprintf("Topic deleted, but unable to confirm it on all brokers, got error: %s", err))
+	}
+}
+
+// deletionProtected returns whether the topic can't be deleted, and how to
+// allow it
+func (r *topicResource) deletionProtected(data *TopicResourceModel) (bool, string) {
+	if data.DeletionProtection.ValueBool() {
+		return true, "as deletion_protection is enabled. Set deletion_protection to false and apply it before deleting or replacing the topic"
+	}
+	for _, pattern := range r.protectedTopicPatterns {
+		if pattern.MatchString(data.Name.ValueString()) {
+			return true, fmt.Sprintf("as it matches the provider protected_topic_patterns entry %q. Remove the entry from protected_topic_patterns to delete or replace the topic", pattern.String())
+		}
+	}
+	return false, ""
+}
+
+// operationContext returns a context with the deadline of the operation, using
+// the timeout from the timeouts block or the provider timeout when it is unset
+func (r *topicResource) operationContext(ctx context.Context, timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics)) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	duration, diags := timeout(ctx, r.client.GetConnector().KafkaClient.Timeout)
+	if diags.HasError() {
+		return ctx, func() {}, diags
+	}
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	return ctx, cancel, diags
 }
 
 func (r *topicResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// replicaAssignmentValue converts the replica_assignment attribute into the
+// brokers for each partition
+func replicaAssignmentValue(ctx context.Context, value types.List) ([][]int, diag.Diagnostics) {
+	var elements [][]int64
+	diags := value.ElementsAs(ctx, &elements, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	replicas := [][]int{}
+	for _, partition := range elements {
+		brokers := []int{}
+		for _, broker := range partition {
+			brokers = append(brokers, int(broker))
+		}
+		replicas = append(replicas, brokers)
+	}
+	return replicas, diags
+}
+
+// replicaAssignmentListValue returns the current replica assignment of a topic
+// as a replica_assignment attribute value
+func replicaAssignmentListValue(ctx context.Context, topicInfo admin.TopicInfo) (types.List, diag.Diagnostics) {
+	assignments := topicInfo.ToAssignments()
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].ID < assignments[j].ID })
+
+	replicas := [][]int64{}
+	for _, assignment := range assignments {
+		brokers := []int64{}
+		for _, broker := range assignment.Replicas {
+			brokers = append(brokers, int64(broker))
+		}
+		replicas = append(replicas, brokers)
+	}
+	return types.ListValueFrom(ctx, types.ListType{ElemType: types.Int64Type}, replicas)
+}
+
+// validateReplicaAssignment checks that the assignment matches the partition
+// count and replication factor, and that no broker is repeated in a partition
+func validateReplicaAssignment(replicas [][]int, partitions int, replicationFactor int) error {
+	if len(replicas) != partitions {
+		return fmt.Errorf("expected %d partitions, got %d", partitions, len(replicas))
+	}
+	for partition, brokers := range replicas {
+		if len(brokers) != replicationFactor {
+			return fmt.Errorf("partition %d: expected %d replicas, got %d", partition, replicationFactor, len(brokers))
+		}
+		seen := map[int]bool{}
+		for _, broker := range brokers {
+			if seen[broker] {
+				return fmt.Errorf("partition %d: broker %d is assigned more than once", partition, broker)
+			}
+			seen[broker] = true
+		}
+	}
+	return nil
+}
@@ -1,11 +1,24 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol/alterpartitionreassignments"
+	"github.com/segmentio/kafka-go/protocol/incrementalalterconfigs"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/topicctl/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -74,6 +87,242 @@ func TestAccTopicResourceGenerateConfig(t *testing.T) {
 	})
 }
 
+func TestAccTopicResourceConfiguration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTopicResourceConfigWithConfiguration("configured", `
+    "retention.ms"   = "3600000"
+    "cleanup.policy" = "compact"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_topic.test", "configuration.%", "2"),
+					resource.TestCheckResourceAttr("kafka_topic.test", "configuration.retention.ms", "3600000"),
+					resource.TestCheckResourceAttr("kafka_topic.test", "configuration.cleanup.policy", "compact"),
+					resource.TestCheckResourceAttr("kafka_topic.test", "effective_configuration.retention.ms", "3600000"),
+					resource.TestCheckResourceAttrSet("kafka_topic.test", "effective_configuration.segment.bytes"),
+				),
+			},
+			// Invalid values are rejected during plan
+			{
+				Config: testAccTopicResourceConfigWithConfiguration("configured", `
+    "cleanup.policy" = "delet"
+`),
+				ExpectError: regexp.MustCompile("Invalid topic configuration"),
+			},
+			// Removing a key reverts only that key to the broker default
+			{
+				Config: testAccTopicResourceConfigWithConfiguration("configured", `
+    "cleanup.policy" = "compact"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("kafka_topic.test", "configuration.retention.ms"),
+					resource.TestCheckResourceAttr("kafka_topic.test", "configuration.cleanup.policy", "compact"),
+					resource.TestCheckResourceAttrSet("kafka_topic.test", "effective_configuration.retention.ms"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTopicResourceTimeouts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccTopicResourceConfigWithTimeouts("timeouts", "delete = \"forever\""),
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Time Duration"),
+			},
+			{
+				Config: testAccTopicResourceConfigWithTimeouts("timeouts", `
+    create = "1m"
+    read   = "30s"
+    update = "2h"
+    delete = "1m"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_topic.test", "timeouts.create", "1m"),
+					resource.TestCheckResourceAttr("kafka_topic.test", "timeouts.update", "2h"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTopicResourceDeletionProtection(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTopicResourceConfigWithDeletionProtection("protected", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_topic.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccTopicResourceConfigWithDeletionProtection("protected", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Topic is protected from deletion"),
+			},
+			// Renames replace the topic, so they are refused too
+			{
+				Config:      testAccTopicResourceConfigWithDeletionProtection("protected-renamed", true),
+				ExpectError: regexp.MustCompile("Topic is protected from deletion"),
+			},
+			{
+				Config: testAccTopicResourceConfigWithDeletionProtection("protected", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_topic.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTopicResourceDestroyBehaviorAbandon(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// The abandoned topic is left in the cluster, so we delete it here
+		CheckDestroy: testAccDeleteTopic("abandoned"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccTopicResourceConfigWithDestroyBehavior("abandoned", "keep"),
+				ExpectError: regexp.MustCompile("Invalid destroy behavior"),
+			},
+			{
+				Config: testAccTopicResourceConfigWithDestroyBehavior("abandoned", "abandon"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_topic.test", "destroy_behavior", "abandon"),
+				),
+			},
+			// Removing the resource leaves the topic in the cluster
+			{
+				Config: testAccTopicDataSourceConfig("abandoned"),
+			},
+			{
+				Config: testAccTopicDataSourceConfig("abandoned"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kafka_topic.test", "name", "abandoned"),
+				),
+			},
+		},
+	})
+}
+
+func TestDeletionProtected(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &topicResource{protectedTopicPatterns: []*regexp.Regexp{regexp.MustCompile(`^prod\.`)}}
+
+	protected, _ := r.deletionProtected(&TopicResourceModel{
+		Name: types.StringValue("staging.orders"),
+	})
+	assert.False(protected)
+
+	protected, reason := r.deletionProtected(&TopicResourceModel{
+		Name:               types.StringValue("staging.orders"),
+		DeletionProtection: types.BoolValue(true),
+	})
+	assert.True(protected)
+	assert.Contains(reason, "deletion_protection")
+
+	protected, reason = r.deletionProtected(&TopicResourceModel{
+		Name:               types.StringValue("prod.orders"),
+		DeletionProtection: types.BoolValue(false),
+	})
+	assert.True(protected, "Matching topics are protected regardless of deletion_protection")
+	assert.Contains(reason, "protected_topic_patterns")
+}
+
+func TestAccTopicResourcePartitionDecrease(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTopicResourceConfigWithRecreate("decrease", 2, false),
+			},
+			// Decreasing partitions fails during plan
+			{
+				Config:      testAccTopicResourceConfigWithRecreate("decrease", 1, false),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Value can't be decreased"),
+			},
+			// Unless the topic can be recreated
+			{
+				Config: testAccTopicResourceConfigWithRecreate("decrease", 1, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("kafka_topic.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_topic.test", "partitions", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTopicResourceReplicaAssignment(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTopicResourceConfigWithReplicaAssignment("assignment", 2, "[1], [1]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_topic.test", "replica_assignment.#", "2"),
+					resource.TestCheckResourceAttr("kafka_topic.test", "replica_assignment.1.0", "1"),
+				),
+			},
+			// Partitions are added with their explicit assignment
+			{
+				Config: testAccTopicResourceConfigWithReplicaAssignment("assignment", 3, "[1], [1], [1]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_topic.test", "partitions", "3"),
+					resource.TestCheckResourceAttr("kafka_topic.test", "replica_assignment.#", "3"),
+				),
+			},
+			// The assignment must match the partition count
+			{
+				Config:      testAccTopicResourceConfigWithReplicaAssignment("assignment", 3, "[1], [1]"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid replica assignment"),
+			},
+		},
+	})
+}
+
+func TestAccTopicResourcePlacement(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTopicResourceConfigWithPlacement("placement", 1, "balanced-leaders"),
+			},
+			// New partitions are placed with the configured strategy
+			{
+				Config: testAccTopicResourceConfigWithPlacement("placement", 2, "balanced-leaders"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_topic.test", "partitions", "2"),
+				),
+			},
+			{
+				Config:      testAccTopicResourceConfigWithPlacement("placement", 2, "static"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Missing replica assignment"),
+			},
+		},
+	})
+}
+
 func testAccTopicResourceConfig(name string, partitions int, replication_factor int) string {
 	return fmt.Sprintf(providerConfig+`
 resource "kafka_topic" "test" {
@@ -84,6 +333,102 @@ resource "kafka_topic" "test" {
 `, name, partitions, replication_factor)
 }
 
+// testAccDeleteTopic deletes a topic left in the cluster by a test
+func testAccDeleteTopic(name string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		client := &kafka.Client{Addr: kafka.TCP("127.0.0.1:9092")}
+		resp, err := client.DeleteTopics(context.Background(), &kafka.DeleteTopicsRequest{Topics: []string{name}})
+		if err != nil {
+			return err
+		}
+		return resp.Errors[name]
+	}
+}
+
+func testAccTopicResourceConfigWithTimeouts(name string, timeouts string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_topic" "test" {
+  name = %[1]q
+  partitions = 1
+  replication_factor = 1
+  timeouts {
+    %[2]s
+  }
+}
+`, name, timeouts)
+}
+
+func testAccTopicResourceConfigWithDeletionProtection(name string, deletionProtection bool) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_topic" "test" {
+  name = %[1]q
+  partitions = 1
+  replication_factor = 1
+  deletion_protection = %[2]t
+}
+`, name, deletionProtection)
+}
+
+func testAccTopicResourceConfigWithDestroyBehavior(name string, destroyBehavior string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_topic" "test" {
+  name = %[1]q
+  partitions = 1
+  replication_factor = 1
+  destroy_behavior = %[2]q
+}
+`, name, destroyBehavior)
+}
+
+func testAccTopicResourceConfigWithConfiguration(name string, configuration string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_topic" "test" {
+  name = %[1]q
+  partitions = 1
+  replication_factor = 1
+  configuration = {
+%[2]s
+  }
+}
+`, name, configuration)
+}
+
+func testAccTopicResourceConfigWithRecreate(name string, partitions int, recreate bool) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_topic" "test" {
+  name = %[1]q
+  partitions = %[2]d
+  replication_factor = 1
+  recreate_on_partition_decrease = %[3]t
+}
+`, name, partitions, recreate)
+}
+
+func testAccTopicResourceConfigWithReplicaAssignment(name string, partitions int, assignment string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_topic" "test" {
+  name = %[1]q
+  partitions = %[2]d
+  replication_factor = 1
+  replica_assignment = [%[3]s]
+}
+`, name, partitions, assignment)
+}
+
+func testAccTopicResourceConfigWithPlacement(name string, partitions int, strategy string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_topic" "test" {
+  name = %[1]q
+  partitions = %[2]d
+  replication_factor = 1
+  placement = {
+    strategy = %[3]q
+    picker = "lowest-index"
+  }
+}
+`, name, partitions, strategy)
+}
+
 func TestIncreaseReplicas(t *testing.T) {
 	assert := assert.New(t)
 	desiredCount := 3
@@ -126,3 +471,204 @@ func TestReduceReplicas(t *testing.T) {
 
 	assert.Equal(expectedReplicas, newReplicas, "Increase replica expected should be the same")
 }
+
+func TestConfigDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	current := map[string]string{
+		"cleanup.policy": "delete",
+		"retention.ms":   "86400000",
+		"segment.bytes":  "1073741824",
+	}
+	desired := map[string]string{
+		"cleanup.policy":      "delete",
+		"retention.ms":        "3600000",
+		"min.insync.replicas": "2",
+	}
+
+	expectedConfigs := []kafka.IncrementalAlterConfigsRequestConfig{
+		{Name: "min.insync.replicas", Value: "2", ConfigOperation: kafka.ConfigOperationSet},
+		{Name: "retention.ms", Value: "3600000", ConfigOperation: kafka.ConfigOperationSet},
+		{Name: "segment.bytes", ConfigOperation: kafka.ConfigOperationDelete},
+	}
+	assert.Equal(expectedConfigs, configDiff(current, desired), "Only changed keys should be set, and removed keys deleted")
+	assert.Empty(configDiff(current, current), "Equal configs should not generate any operation")
+
+	throttled := map[string]string{
+		"cleanup.policy":                          "delete",
+		"leader.replication.throttled.replicas":   "0:1",
+		"follower.replication.throttled.replicas": "0:2",
+	}
+	assert.Empty(configDiff(throttled, map[string]string{"cleanup.policy": "delete"}), "Throttle configs should not be deleted")
+	assert.Equal([]kafka.IncrementalAlterConfigsRequestConfig{
+		{Name: "cleanup.policy", Value: "delete", ConfigOperation: kafka.ConfigOperationSet},
+	}, configDiff(map[string]string{}, throttled), "Throttle configs should not be set")
+}
+
+func TestUpdateReplicationFactorTimeout(t *testing.T) {
+	fastReassignmentPolls(t)
+
+	topics := []metadata.ResponseTopic{
+		{Name: "test", Partitions: []metadata.ResponsePartition{
+			{PartitionIndex: 0, LeaderID: 1, ReplicaNodes: []int32{1}, IsrNodes: []int32{1}},
+		}},
+	}
+
+	testCases := []struct {
+		name        string
+		cancel      bool
+		err         string
+		cancelled   bool
+		unthrottled bool
+	}{
+		{
+			name:        "cancel on timeout",
+			cancel:      true,
+			err:         "the reassignment was cancelled",
+			cancelled:   true,
+			unthrottled: true,
+		},
+		{
+			name:        "continue on timeout",
+			cancel:      false,
+			err:         "the reassignment continues in the background",
+			cancelled:   false,
+			unthrottled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			// The reassignment never completes
+			broker := newFakeBroker(t, topics, reassignmentHandler(-1, 0))
+			r := &topicResource{client: broker.client(t)}
+			state := &TopicResourceModel{
+				Name:              types.StringValue("test"),
+				ReplicationFactor: types.Int64Value(1),
+			}
+			data := &TopicResourceModel{
+				Name:                        types.StringValue("test"),
+				ReplicationFactor:           types.Int64Value(2),
+				CancelReassignmentOnTimeout: types.BoolValue(tc.cancel),
+				ReassignmentThrottle:        &ReassignmentThrottleModel{Rate: types.Int64Value(1000)},
+				Placement: &PlacementModel{
+					Strategy:              types.StringValue("any"),
+					Picker:                types.StringValue("lowest-index"),
+					StaticRackAssignments: types.ListNull(types.StringType),
+				},
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			err := r.updateReplicationFactor(ctx, state, data, fwresource.UpdateRequest{}, &fwresource.UpdateResponse{})
+			assert.ErrorIs(err, errReassignmentTimeout)
+			assert.ErrorContains(err, tc.err)
+
+			cancelled := false
+			for _, alter := range requestsOf[*alterpartitionreassignments.Request](broker.received()) {
+				for _, partition := range alter.Topics[0].Partitions {
+					cancelled = cancelled || partition.Replicas == nil
+				}
+			}
+			assert.Equal(tc.cancelled, cancelled)
+
+			throttled, unthrottled := false, false
+			for _, alter := range requestsOf[*incrementalalterconfigs.Request](broker.received()) {
+				for _, config := range alter.Resources[0].Configs {
+					throttled = throttled || config.ConfigOperation == int8(kafka.ConfigOperationSet)
+					unthrottled = unthrottled || config.ConfigOperation == int8(kafka.ConfigOperationDelete)
+				}
+			}
+			assert.True(throttled)
+			assert.Equal(tc.unthrottled, unthrottled, "Throttles are only removed when the reassignment is cancelled")
+		})
+	}
+}
+
+func TestValidateReplicaAssignment(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(validateReplicaAssignment([][]int{{1, 2}, {2, 3}, {3, 1}}, 3, 2))
+	assert.Error(validateReplicaAssignment([][]int{{1, 2}, {2, 3}}, 3, 2), "Partition count should match")
+	assert.Error(validateReplicaAssignment([][]int{{1, 2}, {2}, {3, 1}}, 3, 2), "Replication factor should match")
+	assert.Error(validateReplicaAssignment([][]int{{1, 1}, {2, 3}, {3, 1}}, 3, 2), "Brokers can't be repeated in a partition")
+}
+
+func TestValidatePlacement(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	topic := func(placement PlacementModel, assignment types.List) *TopicResourceModel {
+		return &TopicResourceModel{
+			Partitions:        types.Int64Value(2),
+			ReplicationFactor: types.Int64Value(1),
+			ReplicaAssignment: assignment,
+			Placement:         &placement,
+		}
+	}
+	noRacks := types.ListNull(types.StringType)
+	noAssignment := types.ListNull(types.ListType{ElemType: types.Int64Type})
+	racks := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")})
+	assignment := types.ListValueMust(types.ListType{ElemType: types.Int64Type}, []attr.Value{
+		types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(1)}),
+		types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(2)}),
+	})
+
+	assert.False(validatePlacement(ctx, topic(PlacementModel{
+		Strategy: types.StringValue("cross-rack"), Picker: types.StringValue("lowest-index"), StaticRackAssignments: noRacks,
+	}, noAssignment)).HasError())
+	assert.False(validatePlacement(ctx, topic(PlacementModel{
+		Strategy: types.StringValue("static"), Picker: types.StringNull(), StaticRackAssignments: noRacks,
+	}, assignment)).HasError())
+	assert.False(validatePlacement(ctx, topic(PlacementModel{
+		Strategy: types.StringValue("static-in-rack"), Picker: types.StringNull(), StaticRackAssignments: racks,
+	}, noAssignment)).HasError())
+
+	assert.True(validatePlacement(ctx, topic(PlacementModel{
+		Strategy: types.StringValue("random"), Picker: types.StringNull(), StaticRackAssignments: noRacks,
+	}, noAssignment)).HasError(), "Unknown strategies should fail")
+	assert.True(validatePlacement(ctx, topic(PlacementModel{
+		Strategy: types.StringValue("any"), Picker: types.StringValue("first"), StaticRackAssignments: noRacks,
+	}, noAssignment)).HasError(), "Unknown pickers should fail")
+	assert.True(validatePlacement(ctx, topic(PlacementModel{
+		Strategy: types.StringValue("static"), Picker: types.StringNull(), StaticRackAssignments: noRacks,
+	}, noAssignment)).HasError(), "The static strategy requires replica_assignment")
+	assert.True(validatePlacement(ctx, topic(PlacementModel{
+		Strategy: types.StringValue("any"), Picker: types.StringNull(), StaticRackAssignments: noRacks,
+	}, assignment)).HasError(), "replica_assignment requires the static strategy")
+	assert.True(validatePlacement(ctx, topic(PlacementModel{
+		Strategy: types.StringValue("static-in-rack"), Picker: types.StringNull(), StaticRackAssignments: noRacks,
+	}, noAssignment)).HasError(), "The static-in-rack strategy requires racks")
+	assert.True(validatePlacement(ctx, topic(PlacementModel{
+		Strategy: types.StringValue("static-in-rack"), Picker: types.StringNull(),
+		StaticRackAssignments: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}),
+	}, noAssignment)).HasError(), "Racks should match the partition count")
+}
+
+func TestPlacementFor(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	strategy, picker, rackAssignments, err := placementFor(ctx, &TopicResourceModel{}, config.PlacementStrategyCrossRack, config.PickerMethodClusterUse)
+	assert.NoError(err)
+	assert.Equal("cross-rack", strategy, "The default strategy should be used without placement")
+	assert.Equal("cluster-use", picker, "The default picker should be used without placement")
+	assert.Nil(rackAssignments)
+
+	placement := &PlacementModel{
+		Strategy:              types.StringValue("balanced-leaders"),
+		Picker:                types.StringNull(),
+		StaticRackAssignments: types.ListNull(types.StringType),
+	}
+	strategy, picker, _, err = placementFor(ctx, &TopicResourceModel{Placement: placement}, config.PlacementStrategyCrossRack, config.PickerMethodClusterUse)
+	assert.NoError(err)
+	assert.Equal("balanced-leaders", strategy)
+	assert.Equal("cluster-use", picker, "The default picker should be used when it is unset")
+
+	placement.Picker = types.StringValue("lowest-index")
+	_, picker, _, err = placementFor(ctx, &TopicResourceModel{Placement: placement}, config.PlacementStrategyCrossRack, config.PickerMethodClusterUse)
+	assert.NoError(err)
+	assert.Equal("lowest-index", picker)
+}
@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ datasource.DataSource                   = &topicsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &topicsDataSource{}
+)
+
+func NewTopicsDataSource() datasource.DataSource {
+	return &topicsDataSource{}
+}
+
+// topicsDataSource defines the data source implementation.
+type topicsDataSource struct {
+	client *admin.BrokerAdminClient
+}
+
+// topicsDataSourceModel describes the data source data model.
+type topicsDataSourceModel struct {
+	ID              types.String            `tfsdk:"id"`
+	NameRegex       types.String            `tfsdk:"name_regex"`
+	NamePrefix      types.String            `tfsdk:"name_prefix"`
+	IncludeInternal types.Bool              `tfsdk:"include_internal"`
+	Topics          []topicsDataSourceTopic `tfsdk:"topics"`
+}
+
+// topicsDataSourceTopic describes each topic of the data source.
+type topicsDataSourceTopic struct {
+	Name              types.String `tfsdk:"name"`
+	Partitions        types.Int64  `tfsdk:"partitions"`
+	ReplicationFactor types.Int64  `tfsdk:"replication_factor"`
+	Config            types.Map    `tfsdk:"configuration"`
+}
+
+func (d *topicsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_topics"
+}
+
+func (d *topicsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Topics data source",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only include the topics with a name matching this regular expression",
+				Optional:            true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only include the topics with a name starting with this prefix",
+				Optional:            true,
+			},
+			"include_internal": schema.BoolAttribute{
+				MarkdownDescription: "Include internal topics, such as `__consumer_offsets` (default: false)",
+				Optional:            true,
+			},
+			"topics": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching topics, sorted by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Topic name",
+							Computed:            true,
+						},
+						"partitions": schema.Int64Attribute{
+							MarkdownDescription: "Topic partitions count",
+							Computed:            true,
+						},
+						"replication_factor": schema.Int64Attribute{
+							MarkdownDescription: "Topic replication factor",
+							Computed:            true,
+						},
+						"configuration": schema.MapAttribute{
+							MarkdownDescription: "Topic configuration",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *topicsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.BrokerAdminClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.BrokerAdminClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *topicsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data topicsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.NameRegex.IsNull() || data.NameRegex.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(data.NameRegex.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+	}
+}
+
+func (d *topicsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data topicsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+	}
+
+	// Filter the names first, so we only describe the matching topics
+	clientResp, err := d.client.GetConnector().KafkaClient.Metadata(ctx, &kafka.MetadataRequest{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list topics, got error: %s", err))
+		return
+	}
+	names := []string{}
+	for _, topic := range clientResp.Topics {
+		if topic.Internal && !data.IncludeInternal.ValueBool() {
+			continue
+		}
+		if !matchTopicName(topic.Name, data.NamePrefix.ValueString(), nameRegex) {
+			continue
+		}
+		names = append(names, topic.Name)
+	}
+
+	topics := []topicsDataSourceTopic{}
+	if len(names) > 0 {
+		topicInfos, err := d.client.GetTopics(ctx, names, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read topics, got error: %s", err))
+			return
+		}
+
+		for _, topicInfo := range topicInfos {
+			// Report non-uniform replication, which happens on interrupted
+			// reassignments, instead of failing to read every topic
+			replicationFactor, err := replicaCount(topicInfo)
+			if err != nil {
+				replicationFactor = maxReplicaCount(topicInfo)
+				resp.Diagnostics.AddWarning(
+					"Non-uniform replication",
+					fmt.Sprintf("Topic %s: %s. The replication_factor is set to the highest replica count %d.", topicInfo.Name, err, replicationFactor),
+				)
+			}
+
+			configElement := make(map[string]attr.Value)
+			for k, v := range topicInfo.Config {
+				configElement[k] = types.StringValue(v)
+			}
+			topics = append(topics, topicsDataSourceTopic{
+				Name:              types.StringValue(topicInfo.Name),
+				Partitions:        types.Int64Value(int64(len(topicInfo.Partitions))),
+				ReplicationFactor: types.Int64Value(int64(replicationFactor)),
+				Config:            types.MapValueMust(types.StringType, configElement),
+			})
+		}
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Name.ValueString() < topics[j].Name.ValueString() })
+
+	data.ID = types.StringValue(strings.Join([]string{
+		data.NamePrefix.ValueString(),
+		data.NameRegex.ValueString(),
+		fmt.Sprintf("%t", data.IncludeInternal.ValueBool()),
+	}, "|"))
+	data.Topics = topics
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matchTopicName returns whether the topic name has the prefix and matches
+// the regular expression, when they are set
+func matchTopicName(name string, prefix string, nameRegex *regexp.Regexp) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	return nameRegex == nil || nameRegex.MatchString(name)
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccTopicsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccTopicsDataSourceConfig(`name_regex = "^read\\.me$"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kafka_topics.test", "topics.#", "1"),
+					resource.TestCheckResourceAttr("data.kafka_topics.test", "topics.0.name", existingTopic),
+					resource.TestCheckResourceAttr("data.kafka_topics.test", "topics.0.partitions", "1"),
+					resource.TestCheckResourceAttr("data.kafka_topics.test", "topics.0.replication_factor", "1"),
+				),
+			},
+			// Internal topics are excluded by default
+			{
+				Config: testAccTopicsDataSourceConfig(`name_prefix = "__consumer_offsets"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kafka_topics.test", "topics.#", "0"),
+				),
+			},
+			// Invalid regular expressions fail validation
+			{
+				Config:      testAccTopicsDataSourceConfig(`name_regex = "("`),
+				ExpectError: regexp.MustCompile("Invalid regular expression"),
+			},
+		},
+	})
+}
+
+func TestMatchTopicName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(matchTopicName("orders.created", "", nil))
+	assert.True(matchTopicName("orders.created", "orders.", nil))
+	assert.False(matchTopicName("payments.created", "orders.", nil))
+	assert.True(matchTopicName("orders.created", "orders.", regexp.MustCompile(`\.created$`)))
+	assert.False(matchTopicName("orders.deleted", "orders.", regexp.MustCompile(`\.created$`)))
+	assert.False(matchTopicName("payments.created", "orders.", regexp.MustCompile(`\.created$`)))
+}
+
+func testAccTopicsDataSourceConfig(filter string) string {
+	return fmt.Sprintf(providerConfig+`
+data "kafka_topics" "test" {
+  %s
+}
+`, filter)
+}
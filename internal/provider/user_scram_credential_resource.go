@@ -0,0 +1,369 @@
+package provider
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/topicctl/pkg/admin"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                   = &userScramCredentialResource{}
+	_ resource.ResourceWithConfigure      = &userScramCredentialResource{}
+	_ resource.ResourceWithImportState    = &userScramCredentialResource{}
+	_ resource.ResourceWithValidateConfig = &userScramCredentialResource{}
+)
+
+const (
+	// Kafka rejects SCRAM iteration counts outside of this range
+	scramMinIterations = 4096
+	scramMaxIterations = 16384
+	scramSaltSize      = 32
+)
+
+func NewUserScramCredentialResource() resource.Resource {
+	return &userScramCredentialResource{}
+}
+
+// userScramCredentialResource defines the resource implementation.
+type userScramCredentialResource struct {
+	client *admin.BrokerAdminClient
+}
+
+// UserScramCredentialResourceModel describes the resource data model.
+type UserScramCredentialResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Username   types.String `tfsdk:"username"`
+	Mechanism  types.String `tfsdk:"mechanism"`
+	Iterations types.Int64  `tfsdk:"iterations"`
+	Password   types.String `tfsdk:"password"`
+}
+
+func (r *userScramCredentialResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_scram_credential"
+}
+
+func (r *userScramCredentialResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Kafka SCRAM user credential resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Credential id, composed of the username and mechanism separated by `|`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mechanism": schema.StringAttribute{
+				MarkdownDescription: "SCRAM mechanism. One of scram-sha-256, scram-sha-512",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"iterations": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of SCRAM iterations, between %d and %d (default: %d)", scramMinIterations, scramMaxIterations, scramMinIterations),
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password",
+				Required:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *userScramCredentialResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*kafkaResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *kafkaResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *userScramCredentialResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data UserScramCredentialResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Mechanism.IsNull() && !data.Mechanism.IsUnknown() {
+		if _, err := scramMechanism(data.Mechanism.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("mechanism"), "Invalid SCRAM mechanism", err.Error())
+		}
+	}
+	if !data.Iterations.IsNull() && !data.Iterations.IsUnknown() {
+		iterations := data.Iterations.ValueInt64()
+		if iterations < scramMinIterations || iterations > scramMaxIterations {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("iterations"),
+				"Invalid SCRAM iterations",
+				fmt.Sprintf("iterations must be between %d and %d, got: %d", scramMinIterations, scramMaxIterations, iterations),
+			)
+		}
+	}
+}
+
+func (r *userScramCredentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *UserScramCredentialResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Iterations.IsUnknown() || data.Iterations.IsNull() {
+		data.Iterations = types.Int64Value(scramMinIterations)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating SCRAM credential for user %s", data.Username.ValueString()))
+	if err := r.upsertCredential(ctx, data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create SCRAM credential, got error: %s", err))
+		return
+	}
+	data.ID = types.StringValue(data.Username.ValueString() + "|" + data.Mechanism.ValueString())
+	tflog.Trace(ctx, "Created SCRAM credential")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *userScramCredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *UserScramCredentialResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mechanism, err := scramMechanism(data.Mechanism.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid SCRAM mechanism", err.Error())
+		return
+	}
+
+	clientResp, err := r.client.GetConnector().KafkaClient.DescribeUserScramCredentials(ctx, &kafka.DescribeUserScramCredentialsRequest{
+		Users: []kafka.UserScramCredentialsUser{
+			{Name: data.Username.ValueString()},
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SCRAM credential, got error: %s", err))
+		return
+	}
+	if clientResp.Error != nil {
+		resp.Diagnostics.AddError("Client Response Error", fmt.Sprintf("Unable to read SCRAM credential, got error: %s", clientResp.Error))
+		return
+	}
+
+	found := false
+	for _, result := range clientResp.Results {
+		if result.Error != nil {
+			if errors.Is(result.Error, kafka.ResourceNotFound) {
+				continue
+			}
+			resp.Diagnostics.AddError("Client Response Error", fmt.Sprintf("Unable to read SCRAM credential, got error: %s", result.Error))
+			return
+		}
+		for _, info := range result.CredentialInfos {
+			if info.Mechanism == mechanism {
+				found = true
+				data.Iterations = types.Int64Value(int64(info.Iterations))
+			}
+		}
+	}
+	if !found {
+		// If the credential does not exist, we remove it and return
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *userScramCredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var data *UserScramCredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Iterations.IsUnknown() || data.Iterations.IsNull() {
+		data.Iterations = types.Int64Value(scramMinIterations)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Rotating SCRAM credential for user %s", data.Username.ValueString()))
+	if err := r.upsertCredential(ctx, data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update SCRAM credential, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *userScramCredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *UserScramCredentialResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mechanism, err := scramMechanism(data.Mechanism.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid SCRAM mechanism", err.Error())
+		return
+	}
+
+	clientResp, err := r.client.GetConnector().KafkaClient.AlterUserScramCredentials(ctx, &kafka.AlterUserScramCredentialsRequest{
+		Deletions: []kafka.UserScramCredentialsDeletion{
+			{
+				Name:      data.Username.ValueString(),
+				Mechanism: mechanism,
+			},
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete SCRAM credential, got error: %s", err))
+		return
+	}
+	for _, result := range clientResp.Results {
+		if result.Error != nil && !errors.Is(result.Error, kafka.ResourceNotFound) {
+			resp.Diagnostics.AddError("Client Response Error", fmt.Sprintf("Unable to delete SCRAM credential, got error: %s", result.Error))
+			return
+		}
+	}
+}
+
+func (r *userScramCredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "|")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: username|mechanism. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mechanism"), parts[1])...)
+}
+
+// upsertCredential salts the password and creates or replaces the credential
+func (r *userScramCredentialResource) upsertCredential(ctx context.Context, data *UserScramCredentialResourceModel) error {
+	mechanism, err := scramMechanism(data.Mechanism.ValueString())
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, scramSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	saltedPassword, err := scramSaltedPassword(mechanism, data.Password.ValueString(), salt, int(data.Iterations.ValueInt64()))
+	if err != nil {
+		return err
+	}
+
+	clientResp, err := r.client.GetConnector().KafkaClient.AlterUserScramCredentials(ctx, &kafka.AlterUserScramCredentialsRequest{
+		Upsertions: []kafka.UserScramCredentialsUpsertion{
+			{
+				Name:           data.Username.ValueString(),
+				Mechanism:      mechanism,
+				Iterations:     int(data.Iterations.ValueInt64()),
+				Salt:           salt,
+				SaltedPassword: saltedPassword,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, result := range clientResp.Results {
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+	return nil
+}
+
+// scramMechanism returns the kafka.ScramMechanism for a mechanism name
+func scramMechanism(name string) (kafka.ScramMechanism, error) {
+	switch admin.SASLMechanism(name) {
+	case admin.SASLMechanismScramSHA256:
+		return kafka.ScramMechanismSha256, nil
+	case admin.SASLMechanismScramSHA512:
+		return kafka.ScramMechanismSha512, nil
+	}
+	return kafka.ScramMechanismUnknown, fmt.Errorf("unknown SCRAM mechanism %q, must be one of: %s, %s", name, admin.SASLMechanismScramSHA256, admin.SASLMechanismScramSHA512)
+}
+
+// scramSaltedPassword computes the SCRAM SaltedPassword (RFC 5802) that the
+// broker stores for a credential
+func scramSaltedPassword(mechanism kafka.ScramMechanism, password string, salt []byte, iterations int) ([]byte, error) {
+	var h func() hash.Hash
+	switch mechanism {
+	case kafka.ScramMechanismSha256:
+		h = sha256.New
+	case kafka.ScramMechanismSha512:
+		h = sha512.New
+	default:
+		return nil, fmt.Errorf("unknown SCRAM mechanism %d", mechanism)
+	}
+	return pbkdf2.Key(h, password, salt, iterations, h().Size())
+}
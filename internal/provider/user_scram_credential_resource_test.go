@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccUserScramCredentialResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccUserScramCredentialResourceConfig("alice", 4096, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_user_scram_credential.test", "id", "alice|scram-sha-512"),
+					resource.TestCheckResourceAttr("kafka_user_scram_credential.test", "iterations", "4096"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "kafka_user_scram_credential.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccUserScramCredentialResourceConfig("alice", 8192, "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kafka_user_scram_credential.test", "iterations", "8192"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccUserScramCredentialResourceConfig(username string, iterations int, password string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "kafka_user_scram_credential" "test" {
+  username   = %[1]q
+  mechanism  = "scram-sha-512"
+  iterations = %[2]d
+  password   = %[3]q
+}
+`, username, iterations, password)
+}
+
+func TestScramSaltedPassword(t *testing.T) {
+	assert := assert.New(t)
+
+	saltedPassword, err := scramSaltedPassword(kafka.ScramMechanismSha256, "password", []byte("salt"), 4096)
+	assert.NoError(err)
+	assert.Equal("c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a", hex.EncodeToString(saltedPassword))
+
+	saltedPassword, err = scramSaltedPassword(kafka.ScramMechanismSha512, "password", []byte("salt"), 4096)
+	assert.NoError(err)
+	assert.Equal("d197b1b33db0143e018b12f3d1d1479e6cdebdcc97c5c0f87f6902e072f457b5143f30602641b3d55cd335988cb36b84376060ecd532e039b742a239434af2d5", hex.EncodeToString(saltedPassword))
+
+	_, err = scramSaltedPassword(kafka.ScramMechanismUnknown, "password", []byte("salt"), 4096)
+	assert.Error(err)
+}
+
+func TestScramMechanism(t *testing.T) {
+	assert := assert.New(t)
+
+	mechanism, err := scramMechanism("scram-sha-256")
+	assert.NoError(err)
+	assert.Equal(kafka.ScramMechanismSha256, mechanism)
+
+	mechanism, err = scramMechanism("scram-sha-512")
+	assert.NoError(err)
+	assert.Equal(kafka.ScramMechanismSha512, mechanism)
+
+	_, err = scramMechanism("plain")
+	assert.Error(err)
+}